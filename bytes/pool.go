@@ -0,0 +1,71 @@
+package bytesext
+
+import (
+	"bytes"
+	"math/bits"
+
+	syncext "github.com/pchchv/extender/sync"
+)
+
+// BufferPool is a pool of *bytes.Buffer split into power of two size classes,
+// so that buffers of a similar size are reused together.
+//
+// Buffers that have grown beyond the max retained size are discarded instead of being returned to the pool,
+// preventing a few large payloads from pinning memory.
+type BufferPool struct {
+	minShift    int
+	maxRetained int
+	classes     []*syncext.Pool[*bytes.Buffer]
+}
+
+// NewBufferPool creates a new BufferPool with size classes from minSize up to maxRetained,
+// both rounded up to the next power of two.
+func NewBufferPool(minSize, maxRetained Bytes) *BufferPool {
+	minShift := max(ceilLog2(minSize), 6)
+	maxShift := max(ceilLog2(maxRetained), minShift)
+
+	p := &BufferPool{
+		minShift:    minShift,
+		maxRetained: 1 << maxShift,
+		classes:     make([]*syncext.Pool[*bytes.Buffer], maxShift-minShift+1),
+	}
+	for i := range p.classes {
+		size := 1 << (minShift + i)
+		p.classes[i] = syncext.NewPool(func() *bytes.Buffer {
+			return bytes.NewBuffer(make([]byte, 0, size))
+		})
+	}
+	return p
+}
+
+// Get returns an empty buffer with a capacity of at least the provided size hint.
+//
+// If the size hint exceeds the max retained size a new buffer is returned.
+func (p *BufferPool) Get(size Bytes) *bytes.Buffer {
+	i := max(ceilLog2(size), p.minShift) - p.minShift
+	if i >= len(p.classes) {
+		return bytes.NewBuffer(make([]byte, 0, size))
+	}
+	return p.classes[i].Get()
+}
+
+// Put resets and returns the buffer to the pool of the largest size class it satisfies,
+// discarding it if its capacity exceeds the max retained size.
+//
+// The buffer, and any slices returned from it, must not be used after calling Put.
+func (p *BufferPool) Put(buf *bytes.Buffer) {
+	c := buf.Cap()
+	if c > p.maxRetained || c < 1<<p.minShift {
+		return
+	}
+	buf.Reset()
+	p.classes[bits.Len(uint(c))-1-p.minShift].Put(buf)
+}
+
+// ceilLog2 returns the exponent of the smallest power of two >= n.
+func ceilLog2(n Bytes) int {
+	if n <= 1 {
+		return 0
+	}
+	return bits.Len64(uint64(n - 1))
+}
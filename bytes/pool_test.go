@@ -0,0 +1,48 @@
+package bytesext
+
+import (
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestBufferPool(t *testing.T) {
+	p := NewBufferPool(100, 4000)
+	Equal(t, p.minShift, 7)
+	Equal(t, p.maxRetained, 4096)
+	Equal(t, len(p.classes), 6)
+
+	buf := p.Get(0)
+	Equal(t, buf.Cap() >= 128, true)
+	Equal(t, buf.Len(), 0)
+
+	buf = p.Get(1000)
+	Equal(t, buf.Cap() >= 1000, true)
+	buf.WriteString("test")
+	p.Put(buf)
+
+	buf = p.Get(1000)
+	Equal(t, buf.Cap() >= 1000, true)
+	Equal(t, buf.Len(), 0)
+
+	// larger than the max retained size
+	buf = p.Get(10000)
+	Equal(t, buf.Cap() >= 10000, true)
+	p.Put(buf)
+
+	// a buffer that is not a power of two is placed in the largest class it satisfies
+	buf = p.Get(256)
+	buf.Grow(600)
+	p.Put(buf)
+	Equal(t, p.Get(512).Cap() >= 512, true)
+}
+
+func BenchmarkBufferPool(b *testing.B) {
+	p := NewBufferPool(512, 64*KiB)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := p.Get(1024)
+		buf.WriteString("benchmark")
+		p.Put(buf)
+	}
+}
@@ -0,0 +1,201 @@
+package cacheext
+
+import (
+	"sync"
+
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// Cache is the common interface implemented by all caches in this package.
+//
+// Implementations are not safe for concurrent use unless wrapped using NewSynchronized.
+type Cache[K comparable, V any] interface {
+	// Get returns the value for the key, if present, recording a hit or miss and the access.
+	Get(key K) optionext.Option[V]
+
+	// Peek returns the value for the key, if present, without recording a hit, miss or access.
+	Peek(key K) optionext.Option[V]
+
+	// Put adds or replaces the value for the key, evicting entries until the cache is within its capacity.
+	Put(key K, value V)
+
+	// Remove removes the key, reporting whether it was present.
+	// The eviction callback is not called for removed entries.
+	Remove(key K) bool
+
+	// Len returns the number of entries in the cache.
+	Len() int
+
+	// Weight returns the total weight of the entries in the cache.
+	Weight() int64
+
+	// Clear removes all entries without calling the eviction callback.
+	Clear()
+
+	// Stats returns the hit, miss and eviction counts of the cache.
+	Stats() Stats
+}
+
+// EvictReason is the reason an entry was evicted from a cache.
+type EvictReason uint8
+
+const (
+	// EvictedCapacity means the entry was evicted to keep the cache within its capacity.
+	EvictedCapacity EvictReason = iota
+
+	// EvictedExpired means the entry was evicted because its TTL elapsed.
+	EvictedExpired
+)
+
+// String returns the name of the reason.
+func (r EvictReason) String() string {
+	switch r {
+	case EvictedCapacity:
+		return "capacity"
+	case EvictedExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// Stats contains the hit, miss and eviction counts of a cache.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRatio returns the ratio of hits to total lookups, or 0 if there have been no lookups.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// base holds the configuration and accounting shared by all caches.
+type base[K comparable, V any] struct {
+	capacity int64
+	weight   int64
+	weigher  func(key K, value V) int64
+	onEvict  func(key K, value V, reason EvictReason)
+	stats    Stats
+}
+
+func newBase[K comparable, V any](capacity int64) base[K, V] {
+	if capacity < 1 {
+		panic("cacheext: capacity must be positive")
+	}
+	return base[K, V]{capacity: capacity}
+}
+
+func (b *base[K, V]) weigh(key K, value V) int64 {
+	if b.weigher == nil {
+		return 1
+	}
+	return b.weigher(key, value)
+}
+
+func (b *base[K, V]) evicted(key K, value V, reason EvictReason) {
+	b.stats.Evictions++
+	if b.onEvict != nil {
+		b.onEvict(key, value, reason)
+	}
+}
+
+func (b *base[K, V]) lookup(found bool) {
+	if found {
+		b.stats.Hits++
+	} else {
+		b.stats.Misses++
+	}
+}
+
+// Synchronized wraps a Cache making it safe for concurrent use.
+//
+// Eviction callbacks are called while the lock is held and must not use the cache.
+type Synchronized[K comparable, V any] struct {
+	mu    sync.Mutex
+	cache Cache[K, V]
+}
+
+// NewSynchronized wraps the provided cache making it safe for concurrent use.
+//
+// The wrapped cache must no longer be used directly.
+func NewSynchronized[K comparable, V any](cache Cache[K, V]) *Synchronized[K, V] {
+	return &Synchronized[K, V]{cache: cache}
+}
+
+// Get returns the value for the key, if present, recording a hit or miss and the access.
+func (s *Synchronized[K, V]) Get(key K) optionext.Option[V] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Get(key)
+}
+
+// Peek returns the value for the key, if present, without recording a hit, miss or access.
+func (s *Synchronized[K, V]) Peek(key K) optionext.Option[V] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Peek(key)
+}
+
+// Put adds or replaces the value for the key, evicting entries until the cache is within its capacity.
+func (s *Synchronized[K, V]) Put(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Put(key, value)
+}
+
+// GetOrPut returns the value for the key if present, otherwise adds and returns the value returned by fn.
+//
+// The function is called while the lock is held, so at most once per missing key,
+// and must not use the cache.
+func (s *Synchronized[K, V]) GetOrPut(key K, fn func(key K) V) V {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v := s.cache.Get(key); v.IsSome() {
+		return v.Unwrap()
+	}
+	v := fn(key)
+	s.cache.Put(key, v)
+	return v
+}
+
+// Remove removes the key, reporting whether it was present.
+func (s *Synchronized[K, V]) Remove(key K) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Remove(key)
+}
+
+// Len returns the number of entries in the cache.
+func (s *Synchronized[K, V]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Len()
+}
+
+// Weight returns the total weight of the entries in the cache.
+func (s *Synchronized[K, V]) Weight() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Weight()
+}
+
+// Clear removes all entries without calling the eviction callback.
+func (s *Synchronized[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Clear()
+}
+
+// Stats returns the hit, miss and eviction counts of the cache.
+func (s *Synchronized[K, V]) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Stats()
+}
@@ -0,0 +1,62 @@
+package cacheext
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+var _ Cache[string, int] = (*Synchronized[string, int])(nil)
+
+func TestEvictReason(t *testing.T) {
+	Equal(t, EvictedCapacity.String(), "capacity")
+	Equal(t, EvictedExpired.String(), "expired")
+	Equal(t, EvictReason(10).String(), "unknown")
+	Equal(t, Stats{}.HitRatio(), 0.0)
+}
+
+func TestSynchronized(t *testing.T) {
+	c := NewSynchronized[string, int](NewLRU[string, int](100))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := strconv.Itoa(j)
+				c.Put(key, j)
+				c.Get(key)
+				c.Peek(key)
+			}
+		}()
+	}
+	wg.Wait()
+	Equal(t, c.Len(), 100)
+	Equal(t, c.Weight(), int64(100))
+	Equal(t, c.Stats().Hits, uint64(1000))
+
+	var calls atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v := c.GetOrPut("new", func(key string) int {
+				calls.Add(1)
+				return 1000
+			})
+			Equal(t, v, 1000)
+		}()
+	}
+	wg.Wait()
+	Equal(t, calls.Load(), int32(1))
+	Equal(t, c.Peek("new"), optionext.Some(1000))
+
+	Equal(t, c.Remove("new"), true)
+	c.Clear()
+	Equal(t, c.Len(), 0)
+}
@@ -0,0 +1,195 @@
+package cacheext
+
+import (
+	listext "github.com/pchchv/extender/container/list"
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// lfuBucket holds all entries with the same access frequency, most recently used first.
+type lfuBucket[K comparable, V any] struct {
+	freq    uint64
+	entries *listext.DoublyLinkedList[lfuEntry[K, V]]
+}
+
+type lfuEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	weight int64
+	bucket *listext.Node[*lfuBucket[K, V]]
+}
+
+// LFU is a bounded cache evicting the least frequently used entries first,
+// and the least recently used among entries with the same frequency.
+//
+// All operations are O(1).
+type LFU[K comparable, V any] struct {
+	base[K, V]
+	items map[K]*listext.Node[lfuEntry[K, V]]
+	// buckets are ordered by ascending frequency.
+	buckets *listext.DoublyLinkedList[*lfuBucket[K, V]]
+}
+
+// NewLFU creates a new LFU cache with the provided capacity for use.
+//
+// It panics if the capacity is not positive.
+//
+// Default settings are:
+//   - Each entry has a weight of 1, so capacity is the maximum number of entries.
+//   - No OnEvict callback.
+func NewLFU[K comparable, V any](capacity int64) *LFU[K, V] {
+	return &LFU[K, V]{
+		base:    newBase[K, V](capacity),
+		items:   make(map[K]*listext.Node[lfuEntry[K, V]]),
+		buckets: listext.NewDoublyLinked[*lfuBucket[K, V]](),
+	}
+}
+
+// Weigher sets the function returning the weight of an entry, capacity then limits the total weight of all entries.
+// An entry heavier than the capacity is evicted as soon as it is added.
+//
+// It must be called before the cache is used.
+func (c *LFU[K, V]) Weigher(fn func(key K, value V) int64) *LFU[K, V] {
+	c.weigher = fn
+	return c
+}
+
+// OnEvict sets the callback called for each entry evicted from the cache.
+func (c *LFU[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) *LFU[K, V] {
+	c.onEvict = fn
+	return c
+}
+
+// Get returns the value for the key, if present, incrementing its frequency.
+func (c *LFU[K, V]) Get(key K) optionext.Option[V] {
+	node, found := c.items[key]
+	c.lookup(found)
+	if !found {
+		return optionext.None[V]()
+	}
+	c.touch(node)
+	return optionext.Some(node.Value.value)
+}
+
+// Peek returns the value for the key, if present, without incrementing its frequency.
+func (c *LFU[K, V]) Peek(key K) optionext.Option[V] {
+	if node, found := c.items[key]; found {
+		return optionext.Some(node.Value.value)
+	}
+	return optionext.None[V]()
+}
+
+// Frequency returns the access frequency of the key, 0 if not present.
+func (c *LFU[K, V]) Frequency(key K) uint64 {
+	if node, found := c.items[key]; found {
+		return node.Value.bucket.Value.freq
+	}
+	return 0
+}
+
+// Put adds or replaces the value for the key, incrementing its frequency,
+// and evicts the least frequently used entries until the cache is within its capacity.
+//
+// The entry being put is evicted last, only if it alone exceeds the capacity,
+// as a newly added entry would otherwise always have the lowest frequency.
+func (c *LFU[K, V]) Put(key K, value V) {
+	weight := c.weigh(key, value)
+	if node, found := c.items[key]; found {
+		c.weight += weight - node.Value.weight
+		node.Value.value, node.Value.weight = value, weight
+		c.touch(node)
+	} else {
+		first := c.buckets.Front()
+		if first == nil || first.Value.freq != 1 {
+			first = c.buckets.PushFront(&lfuBucket[K, V]{
+				freq:    1,
+				entries: listext.NewDoublyLinked[lfuEntry[K, V]](),
+			})
+		}
+		c.weight += weight
+		c.items[key] = first.Value.entries.PushFront(lfuEntry[K, V]{
+			key:    key,
+			value:  value,
+			weight: weight,
+			bucket: first,
+		})
+	}
+
+	for c.weight > c.capacity {
+		node := c.victim(key)
+		c.remove(node)
+		c.evicted(node.Value.key, node.Value.value, EvictedCapacity)
+	}
+}
+
+// Remove removes the key, reporting whether it was present.
+func (c *LFU[K, V]) Remove(key K) bool {
+	node, found := c.items[key]
+	if found {
+		c.remove(node)
+	}
+	return found
+}
+
+// Len returns the number of entries in the cache.
+func (c *LFU[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Weight returns the total weight of the entries in the cache.
+func (c *LFU[K, V]) Weight() int64 {
+	return c.weight
+}
+
+// Clear removes all entries without calling the eviction callback.
+func (c *LFU[K, V]) Clear() {
+	c.buckets.Clear()
+	clear(c.items)
+	c.weight = 0
+}
+
+// Stats returns the hit, miss and eviction counts of the cache.
+func (c *LFU[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// victim returns the least frequently used entry other than the provided key,
+// or the entry of the key itself if it is the only entry.
+func (c *LFU[K, V]) victim(key K) *listext.Node[lfuEntry[K, V]] {
+	for bucket := c.buckets.Front(); bucket != nil; bucket = bucket.Next() {
+		for node := bucket.Value.entries.Back(); node != nil; node = node.Prev() {
+			if node.Value.key != key {
+				return node
+			}
+		}
+	}
+	return c.items[key]
+}
+
+// touch moves the entry into the bucket of the next frequency.
+func (c *LFU[K, V]) touch(node *listext.Node[lfuEntry[K, V]]) {
+	current := node.Value.bucket
+	next := current.Next()
+	if next == nil || next.Value.freq != current.Value.freq+1 {
+		next = c.buckets.PushAfter(current, &lfuBucket[K, V]{
+			freq:    current.Value.freq + 1,
+			entries: listext.NewDoublyLinked[lfuEntry[K, V]](),
+		})
+	}
+
+	current.Value.entries.Remove(node)
+	if current.Value.entries.IsEmpty() {
+		c.buckets.Remove(current)
+	}
+	next.Value.entries.InsertAtFront(node)
+	node.Value.bucket = next
+}
+
+func (c *LFU[K, V]) remove(node *listext.Node[lfuEntry[K, V]]) {
+	bucket := node.Value.bucket
+	bucket.Value.entries.Remove(node)
+	if bucket.Value.entries.IsEmpty() {
+		c.buckets.Remove(bucket)
+	}
+	delete(c.items, node.Value.key)
+	c.weight -= node.Value.weight
+}
@@ -0,0 +1,76 @@
+package cacheext
+
+import (
+	"testing"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+var _ Cache[string, int] = (*LFU[string, int])(nil)
+
+func TestLFU(t *testing.T) {
+	var evicted []string
+	c := NewLFU[string, int](3).OnEvict(func(key string, value int, reason EvictReason) {
+		Equal(t, reason, EvictedCapacity)
+		evicted = append(evicted, key)
+	})
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	Equal(t, c.Get("a"), optionext.Some(1))
+	Equal(t, c.Get("a"), optionext.Some(1))
+	Equal(t, c.Get("b"), optionext.Some(2))
+	Equal(t, c.Frequency("a"), uint64(3))
+	Equal(t, c.Frequency("b"), uint64(2))
+	Equal(t, c.Frequency("c"), uint64(1))
+	Equal(t, c.Frequency("z"), uint64(0))
+
+	c.Put("d", 4)
+	Equal(t, evicted, []string{"c"})
+
+	// least recently used among the same frequency is evicted first
+	c.Put("e", 5)
+	Equal(t, evicted, []string{"c", "d"})
+
+	Equal(t, c.Get("e"), optionext.Some(5))
+	Equal(t, c.Get("e"), optionext.Some(5))
+	c.Put("f", 6)
+	Equal(t, evicted, []string{"c", "d", "b"})
+
+	// peek does not change the frequency
+	Equal(t, c.Peek("f"), optionext.Some(6))
+	Equal(t, c.Frequency("f"), uint64(1))
+
+	Equal(t, c.Remove("a"), true)
+	Equal(t, c.Remove("a"), false)
+	Equal(t, c.Get("a"), optionext.None[int]())
+	Equal(t, c.Len(), 2)
+	Equal(t, c.Stats(), Stats{Hits: 5, Misses: 1, Evictions: 3})
+
+	c.Clear()
+	Equal(t, c.Len(), 0)
+	c.Put("g", 7)
+	Equal(t, c.Frequency("g"), uint64(1))
+}
+
+func TestLFUWeigher(t *testing.T) {
+	var evicted []string
+	c := NewLFU[string, string](6).
+		Weigher(func(key, value string) int64 {
+			return int64(len(value))
+		}).
+		OnEvict(func(key, value string, reason EvictReason) {
+			evicted = append(evicted, key)
+		})
+
+	c.Put("a", "aa")
+	c.Put("b", "bb")
+	c.Put("c", "cc")
+	c.Get("a")
+	c.Get("c")
+	c.Put("d", "dddd")
+	Equal(t, evicted, []string{"b", "a"})
+	Equal(t, c.Weight(), int64(6))
+}
@@ -0,0 +1,125 @@
+package cacheext
+
+import (
+	listext "github.com/pchchv/extender/container/list"
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+type lruEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	weight int64
+}
+
+// LRU is a bounded cache evicting the least recently used entries first.
+type LRU[K comparable, V any] struct {
+	base[K, V]
+	items map[K]*listext.Node[lruEntry[K, V]]
+	list  *listext.DoublyLinkedList[lruEntry[K, V]]
+}
+
+// NewLRU creates a new LRU cache with the provided capacity for use.
+//
+// It panics if the capacity is not positive.
+//
+// Default settings are:
+//   - Each entry has a weight of 1, so capacity is the maximum number of entries.
+//   - No OnEvict callback.
+func NewLRU[K comparable, V any](capacity int64) *LRU[K, V] {
+	return &LRU[K, V]{
+		base:  newBase[K, V](capacity),
+		items: make(map[K]*listext.Node[lruEntry[K, V]]),
+		list:  listext.NewDoublyLinked[lruEntry[K, V]](),
+	}
+}
+
+// Weigher sets the function returning the weight of an entry, capacity then limits the total weight of all entries.
+// An entry heavier than the capacity is evicted as soon as it is added.
+//
+// It must be called before the cache is used.
+func (c *LRU[K, V]) Weigher(fn func(key K, value V) int64) *LRU[K, V] {
+	c.weigher = fn
+	return c
+}
+
+// OnEvict sets the callback called for each entry evicted from the cache.
+func (c *LRU[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) *LRU[K, V] {
+	c.onEvict = fn
+	return c
+}
+
+// Get returns the value for the key, if present, marking it as the most recently used.
+func (c *LRU[K, V]) Get(key K) optionext.Option[V] {
+	node, found := c.items[key]
+	c.lookup(found)
+	if !found {
+		return optionext.None[V]()
+	}
+	c.list.MoveToFront(node)
+	return optionext.Some(node.Value.value)
+}
+
+// Peek returns the value for the key, if present, without marking it as used.
+func (c *LRU[K, V]) Peek(key K) optionext.Option[V] {
+	if node, found := c.items[key]; found {
+		return optionext.Some(node.Value.value)
+	}
+	return optionext.None[V]()
+}
+
+// Put adds or replaces the value for the key, marking it as the most recently used,
+// and evicts the least recently used entries until the cache is within its capacity.
+func (c *LRU[K, V]) Put(key K, value V) {
+	weight := c.weigh(key, value)
+	if node, found := c.items[key]; found {
+		c.weight += weight - node.Value.weight
+		node.Value.value, node.Value.weight = value, weight
+		c.list.MoveToFront(node)
+	} else {
+		c.weight += weight
+		c.items[key] = c.list.PushFront(lruEntry[K, V]{key: key, value: value, weight: weight})
+	}
+
+	for c.weight > c.capacity {
+		node := c.list.Back()
+		c.remove(node)
+		c.evicted(node.Value.key, node.Value.value, EvictedCapacity)
+	}
+}
+
+// Remove removes the key, reporting whether it was present.
+func (c *LRU[K, V]) Remove(key K) bool {
+	node, found := c.items[key]
+	if found {
+		c.remove(node)
+	}
+	return found
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRU[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Weight returns the total weight of the entries in the cache.
+func (c *LRU[K, V]) Weight() int64 {
+	return c.weight
+}
+
+// Clear removes all entries without calling the eviction callback.
+func (c *LRU[K, V]) Clear() {
+	c.list.Clear()
+	clear(c.items)
+	c.weight = 0
+}
+
+// Stats returns the hit, miss and eviction counts of the cache.
+func (c *LRU[K, V]) Stats() Stats {
+	return c.stats
+}
+
+func (c *LRU[K, V]) remove(node *listext.Node[lruEntry[K, V]]) {
+	c.list.Remove(node)
+	delete(c.items, node.Value.key)
+	c.weight -= node.Value.weight
+}
@@ -0,0 +1,79 @@
+package cacheext
+
+import (
+	"testing"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+var _ Cache[string, int] = (*LRU[string, int])(nil)
+
+func TestLRU(t *testing.T) {
+	var evicted []string
+	c := NewLRU[string, int](2).OnEvict(func(key string, value int, reason EvictReason) {
+		Equal(t, reason, EvictedCapacity)
+		evicted = append(evicted, key)
+	})
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	Equal(t, c.Get("a"), optionext.Some(1))
+	c.Put("c", 3)
+	Equal(t, evicted, []string{"b"})
+	Equal(t, c.Get("b"), optionext.None[int]())
+	Equal(t, c.Len(), 2)
+
+	// replacing marks as used
+	c.Put("a", 10)
+	c.Put("d", 4)
+	Equal(t, evicted, []string{"b", "c"})
+	Equal(t, c.Peek("a"), optionext.Some(10))
+
+	// peek does not mark as used
+	Equal(t, c.Peek("a"), optionext.Some(10))
+	c.Put("e", 5)
+	Equal(t, evicted, []string{"b", "c", "a"})
+
+	Equal(t, c.Remove("d"), true)
+	Equal(t, c.Remove("d"), false)
+	Equal(t, c.Len(), 1)
+	Equal(t, c.Stats(), Stats{Hits: 1, Misses: 1, Evictions: 3})
+	Equal(t, c.Stats().HitRatio(), 0.5)
+
+	c.Clear()
+	Equal(t, c.Len(), 0)
+	Equal(t, c.Weight(), int64(0))
+	Equal(t, len(evicted), 3)
+}
+
+func TestLRUWeigher(t *testing.T) {
+	var evicted []string
+	c := NewLRU[string, string](10).
+		Weigher(func(key, value string) int64 {
+			return int64(len(value))
+		}).
+		OnEvict(func(key, value string, reason EvictReason) {
+			evicted = append(evicted, key)
+		})
+
+	c.Put("a", "aaaa")
+	c.Put("b", "bbbb")
+	Equal(t, c.Weight(), int64(8))
+	c.Put("c", "cc")
+	Equal(t, c.Weight(), int64(10))
+	Equal(t, len(evicted), 0)
+
+	// growing an entry evicts others
+	c.Put("c", "cccccc")
+	Equal(t, evicted, []string{"a"})
+	Equal(t, c.Weight(), int64(10))
+
+	// an entry heavier than the capacity is evicted immediately
+	c.Put("d", "ddddddddddd")
+	Equal(t, evicted, []string{"a", "b", "c", "d"})
+	Equal(t, c.Len(), 0)
+	Equal(t, c.Weight(), int64(0))
+
+	PanicMatches(t, func() { NewLRU[string, string](0) }, "cacheext: capacity must be positive")
+}
@@ -0,0 +1,163 @@
+package cacheext
+
+import (
+	"time"
+
+	listext "github.com/pchchv/extender/container/list"
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+type ttlEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	weight  int64
+	expires time.Time
+}
+
+// TTL is a bounded cache whose entries expire a fixed duration after they were last put.
+//
+// Expired entries are removed lazily when accessed, when evicting to make room or by calling RemoveExpired.
+// When over capacity the entries closest to expiring are evicted first.
+type TTL[K comparable, V any] struct {
+	base[K, V]
+	ttl   time.Duration
+	items map[K]*listext.Node[ttlEntry[K, V]]
+	// list is ordered by expiry, earliest first.
+	list *listext.DoublyLinkedList[ttlEntry[K, V]]
+}
+
+// NewTTL creates a new TTL cache with the provided capacity and time to live for use.
+//
+// It panics if the capacity is not positive.
+//
+// Default settings are:
+//   - Each entry has a weight of 1, so capacity is the maximum number of entries.
+//   - No OnEvict callback.
+func NewTTL[K comparable, V any](capacity int64, ttl time.Duration) *TTL[K, V] {
+	return &TTL[K, V]{
+		base:  newBase[K, V](capacity),
+		ttl:   ttl,
+		items: make(map[K]*listext.Node[ttlEntry[K, V]]),
+		list:  listext.NewDoublyLinked[ttlEntry[K, V]](),
+	}
+}
+
+// Weigher sets the function returning the weight of an entry, capacity then limits the total weight of all entries.
+// An entry heavier than the capacity is evicted as soon as it is added.
+//
+// It must be called before the cache is used.
+func (c *TTL[K, V]) Weigher(fn func(key K, value V) int64) *TTL[K, V] {
+	c.weigher = fn
+	return c
+}
+
+// OnEvict sets the callback called for each entry evicted from the cache, including expired entries.
+func (c *TTL[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) *TTL[K, V] {
+	c.onEvict = fn
+	return c
+}
+
+// Get returns the value for the key, if present and not expired.
+//
+// An expired entry is evicted and counted as a miss.
+func (c *TTL[K, V]) Get(key K) optionext.Option[V] {
+	node, found := c.items[key]
+	if found && !time.Now().Before(node.Value.expires) {
+		c.remove(node)
+		c.evicted(node.Value.key, node.Value.value, EvictedExpired)
+		found = false
+	}
+	c.lookup(found)
+	if !found {
+		return optionext.None[V]()
+	}
+	return optionext.Some(node.Value.value)
+}
+
+// Peek returns the value for the key, if present and not expired, without recording a hit or miss.
+func (c *TTL[K, V]) Peek(key K) optionext.Option[V] {
+	if node, found := c.items[key]; found && time.Now().Before(node.Value.expires) {
+		return optionext.Some(node.Value.value)
+	}
+	return optionext.None[V]()
+}
+
+// Put adds or replaces the value for the key, resetting its expiry,
+// and evicts entries, expired ones first, until the cache is within its capacity.
+func (c *TTL[K, V]) Put(key K, value V) {
+	now := time.Now()
+	weight := c.weigh(key, value)
+	if node, found := c.items[key]; found {
+		c.weight += weight - node.Value.weight
+		node.Value.value, node.Value.weight = value, weight
+		node.Value.expires = now.Add(c.ttl)
+		c.list.MoveToBack(node)
+	} else {
+		c.weight += weight
+		c.items[key] = c.list.PushBack(ttlEntry[K, V]{
+			key:     key,
+			value:   value,
+			weight:  weight,
+			expires: now.Add(c.ttl),
+		})
+	}
+
+	for c.weight > c.capacity {
+		node := c.list.Front()
+		c.remove(node)
+		if now.Before(node.Value.expires) {
+			c.evicted(node.Value.key, node.Value.value, EvictedCapacity)
+		} else {
+			c.evicted(node.Value.key, node.Value.value, EvictedExpired)
+		}
+	}
+}
+
+// RemoveExpired evicts all expired entries, returning the number evicted.
+func (c *TTL[K, V]) RemoveExpired() int {
+	now := time.Now()
+	var n int
+	for node := c.list.Front(); node != nil && !now.Before(node.Value.expires); node = c.list.Front() {
+		c.remove(node)
+		c.evicted(node.Value.key, node.Value.value, EvictedExpired)
+		n++
+	}
+	return n
+}
+
+// Remove removes the key, reporting whether it was present, even if expired.
+func (c *TTL[K, V]) Remove(key K) bool {
+	node, found := c.items[key]
+	if found {
+		c.remove(node)
+	}
+	return found
+}
+
+// Len returns the number of entries in the cache, including expired entries not yet removed.
+func (c *TTL[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Weight returns the total weight of the entries in the cache, including expired entries not yet removed.
+func (c *TTL[K, V]) Weight() int64 {
+	return c.weight
+}
+
+// Clear removes all entries without calling the eviction callback.
+func (c *TTL[K, V]) Clear() {
+	c.list.Clear()
+	clear(c.items)
+	c.weight = 0
+}
+
+// Stats returns the hit, miss and eviction counts of the cache.
+func (c *TTL[K, V]) Stats() Stats {
+	return c.stats
+}
+
+func (c *TTL[K, V]) remove(node *listext.Node[ttlEntry[K, V]]) {
+	c.list.Remove(node)
+	delete(c.items, node.Value.key)
+	c.weight -= node.Value.weight
+}
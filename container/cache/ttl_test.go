@@ -0,0 +1,53 @@
+package cacheext
+
+import (
+	"testing"
+	"time"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+var _ Cache[string, int] = (*TTL[string, int])(nil)
+
+func TestTTL(t *testing.T) {
+	evicted := make(map[string]EvictReason)
+	c := NewTTL[string, int](2, 20*time.Millisecond).OnEvict(func(key string, value int, reason EvictReason) {
+		evicted[key] = reason
+	})
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	Equal(t, c.Get("a"), optionext.Some(1))
+	c.Put("c", 3)
+	Equal(t, evicted, map[string]EvictReason{"a": EvictedCapacity})
+
+	time.Sleep(30 * time.Millisecond)
+	Equal(t, c.Peek("b"), optionext.None[int]())
+	Equal(t, c.Get("b"), optionext.None[int]())
+	Equal(t, evicted["b"], EvictedExpired)
+	Equal(t, c.Len(), 1)
+	Equal(t, c.RemoveExpired(), 1)
+	Equal(t, evicted["c"], EvictedExpired)
+	Equal(t, c.Len(), 0)
+
+	// putting resets the expiry
+	c.Put("d", 4)
+	time.Sleep(15 * time.Millisecond)
+	c.Put("d", 5)
+	time.Sleep(15 * time.Millisecond)
+	Equal(t, c.Get("d"), optionext.Some(5))
+	Equal(t, c.Stats(), Stats{Hits: 2, Misses: 1, Evictions: 3})
+
+	// expired entries are evicted as expired when making room
+	time.Sleep(30 * time.Millisecond)
+	c.Put("e", 6)
+	c.Put("f", 7)
+	Equal(t, evicted["d"], EvictedExpired)
+	Equal(t, c.Len(), 2)
+
+	Equal(t, c.Remove("e"), true)
+	c.Clear()
+	Equal(t, c.Len(), 0)
+	Equal(t, c.Weight(), int64(0))
+}
@@ -0,0 +1,84 @@
+package heapext
+
+import (
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// Entry is a key and its priority within an IndexedHeap.
+type Entry[K comparable, P any] struct {
+	Key      K
+	Priority P
+}
+
+// IndexedHeap is a min-heap of unique keys ordered by their priority, where the priority of a key
+// can be looked up, changed or the key removed by key in O(log n), eg. for scheduling timers.
+//
+// It is not safe for concurrent use.
+type IndexedHeap[K comparable, P any] struct {
+	pq    *PriorityQueue[Entry[K, P]]
+	items map[K]*Item[Entry[K, P]]
+}
+
+// NewIndexedHeap creates a new IndexedHeap ordered by the provided priority less function for use,
+// eg. `time.Time.Before` for timers.
+func NewIndexedHeap[K comparable, P any](less func(i P, j P) bool) *IndexedHeap[K, P] {
+	return &IndexedHeap[K, P]{
+		pq: NewPriorityQueue(func(i, j Entry[K, P]) bool {
+			return less(i.Priority, j.Priority)
+		}),
+		items: make(map[K]*Item[Entry[K, P]]),
+	}
+}
+
+// Push adds the key with the provided priority, or updates its priority if already present.
+func (h *IndexedHeap[K, P]) Push(key K, priority P) {
+	entry := Entry[K, P]{Key: key, Priority: priority}
+	if item, found := h.items[key]; found {
+		h.pq.Update(item, entry)
+		return
+	}
+	h.items[key] = h.pq.Push(entry)
+}
+
+// Pop removes and returns the entry with the least priority, or None if the heap is empty.
+func (h *IndexedHeap[K, P]) Pop() optionext.Option[Entry[K, P]] {
+	entry := h.pq.Pop()
+	if entry.IsSome() {
+		delete(h.items, entry.Unwrap().Key)
+	}
+	return entry
+}
+
+// Peek returns the entry with the least priority without removing it, or None if the heap is empty.
+func (h *IndexedHeap[K, P]) Peek() optionext.Option[Entry[K, P]] {
+	return h.pq.Peek()
+}
+
+// Get returns the priority of the key, if present.
+func (h *IndexedHeap[K, P]) Get(key K) optionext.Option[P] {
+	if item, found := h.items[key]; found {
+		return optionext.Some(item.Value.Priority)
+	}
+	return optionext.None[P]()
+}
+
+// Remove removes the key, reporting whether it was present.
+func (h *IndexedHeap[K, P]) Remove(key K) bool {
+	item, found := h.items[key]
+	if found {
+		h.pq.Remove(item)
+		delete(h.items, key)
+	}
+	return found
+}
+
+// Len returns the number of keys in the heap.
+func (h *IndexedHeap[K, P]) Len() int {
+	return h.pq.Len()
+}
+
+// Clear removes all keys from the heap.
+func (h *IndexedHeap[K, P]) Clear() {
+	h.pq.Clear()
+	clear(h.items)
+}
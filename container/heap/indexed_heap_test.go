@@ -0,0 +1,40 @@
+package heapext
+
+import (
+	"testing"
+	"time"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	now := time.Now()
+	h := NewIndexedHeap[string](time.Time.Before)
+	Equal(t, h.Pop(), optionext.None[Entry[string, time.Time]]())
+
+	h.Push("a", now.Add(3*time.Second))
+	h.Push("b", now.Add(1*time.Second))
+	h.Push("c", now.Add(2*time.Second))
+	Equal(t, h.Len(), 3)
+	Equal(t, h.Peek(), optionext.Some(Entry[string, time.Time]{Key: "b", Priority: now.Add(time.Second)}))
+
+	// reschedule
+	h.Push("a", now)
+	Equal(t, h.Len(), 3)
+	Equal(t, h.Get("a"), optionext.Some(now))
+	Equal(t, h.Get("z"), optionext.None[time.Time]())
+
+	Equal(t, h.Remove("c"), true)
+	Equal(t, h.Remove("c"), false)
+
+	Equal(t, h.Pop().Unwrap().Key, "a")
+	Equal(t, h.Pop().Unwrap().Key, "b")
+	Equal(t, h.Len(), 0)
+	Equal(t, h.Get("a"), optionext.None[time.Time]())
+
+	h.Push("d", now)
+	h.Clear()
+	Equal(t, h.Len(), 0)
+	Equal(t, h.Get("d"), optionext.None[time.Time]())
+}
@@ -0,0 +1,216 @@
+package heapext
+
+import (
+	"iter"
+
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// Item is a handle to a value in a PriorityQueue, used to update or remove it.
+type Item[T any] struct {
+	// Value is the value of the item,
+	// after changing it in place FixAt or Fix must be called to restore the ordering.
+	Value T
+	index int
+}
+
+// Index returns the current index of the item within the queue, or -1 if it has been removed.
+func (i *Item[T]) Index() int {
+	return i.index
+}
+
+// PriorityQueue is a binary heap ordered by the provided less function,
+// where the least value is at the head of the queue.
+//
+// It is not safe for concurrent use.
+type PriorityQueue[T any] struct {
+	items []*Item[T]
+	less  func(i T, j T) bool
+	bound int
+}
+
+// NewPriorityQueue creates a new PriorityQueue ordered by the provided less function for use.
+//
+// Default settings are:
+//   - No Bound, the queue grows without limit.
+func NewPriorityQueue[T any](less func(i T, j T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		less: less,
+	}
+}
+
+// Bound limits the queue to k values, retaining only the k greatest values according to the less function,
+// turning it into a top-K queue whose head is the least of the retained values.
+//
+// It panics if k is less than 1 and must be called before the queue is used.
+func (pq *PriorityQueue[T]) Bound(k int) *PriorityQueue[T] {
+	if k < 1 {
+		panic("heapext: bound must be positive")
+	}
+	pq.bound = k
+	pq.items = make([]*Item[T], 0, k)
+	return pq
+}
+
+// Push adds the value to the queue, returning its handle.
+//
+// When bounded and full, the head is discarded to make room if the value is greater than it,
+// otherwise the value is discarded and nil is returned.
+func (pq *PriorityQueue[T]) Push(v T) *Item[T] {
+	if pq.bound > 0 && len(pq.items) == pq.bound {
+		if !pq.less(pq.items[0].Value, v) {
+			return nil
+		}
+		pq.items[0].index = -1
+		item := &Item[T]{Value: v, index: 0}
+		pq.items[0] = item
+		pq.down(0, len(pq.items))
+		return item
+	}
+
+	item := &Item[T]{Value: v, index: len(pq.items)}
+	pq.items = append(pq.items, item)
+	pq.up(item.index)
+	return item
+}
+
+// Pop removes and returns the least value, or None if the queue is empty.
+func (pq *PriorityQueue[T]) Pop() optionext.Option[T] {
+	if len(pq.items) == 0 {
+		return optionext.None[T]()
+	}
+	n := len(pq.items) - 1
+	pq.swap(0, n)
+	pq.down(0, n)
+	return optionext.Some(pq.removeLast().Value)
+}
+
+// Peek returns the least value without removing it, or None if the queue is empty.
+func (pq *PriorityQueue[T]) Peek() optionext.Option[T] {
+	if len(pq.items) == 0 {
+		return optionext.None[T]()
+	}
+	return optionext.Some(pq.items[0].Value)
+}
+
+// Update sets the value of the item and restores the ordering, reporting whether the item is in the queue.
+func (pq *PriorityQueue[T]) Update(item *Item[T], v T) bool {
+	if !pq.contains(item) {
+		return false
+	}
+	item.Value = v
+	pq.FixAt(item.index)
+	return true
+}
+
+// Fix restores the ordering after the value of the item has been changed in place,
+// reporting whether the item is in the queue.
+func (pq *PriorityQueue[T]) Fix(item *Item[T]) bool {
+	if !pq.contains(item) {
+		return false
+	}
+	pq.FixAt(item.index)
+	return true
+}
+
+// FixAt restores the ordering after the value at index i has been changed in place.
+//
+// It panics if i is out of range.
+func (pq *PriorityQueue[T]) FixAt(i int) {
+	if !pq.down(i, len(pq.items)) {
+		pq.up(i)
+	}
+}
+
+// Remove removes the item from the queue, reporting whether it was in the queue.
+func (pq *PriorityQueue[T]) Remove(item *Item[T]) bool {
+	if !pq.contains(item) {
+		return false
+	}
+	i, n := item.index, len(pq.items)-1
+	if i != n {
+		pq.swap(i, n)
+		if !pq.down(i, n) {
+			pq.up(i)
+		}
+	}
+	pq.removeLast()
+	return true
+}
+
+// Len returns the number of values in the queue.
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// Clear removes all values from the queue.
+func (pq *PriorityQueue[T]) Clear() {
+	for _, item := range pq.items {
+		item.index = -1
+	}
+	clear(pq.items)
+	pq.items = pq.items[:0]
+}
+
+// Drain returns an iterator popping the values from the queue in order, least first.
+//
+// Values not iterated over remain in the queue.
+func (pq *PriorityQueue[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(pq.items) > 0 {
+			if !yield(pq.Pop().Unwrap()) {
+				return
+			}
+		}
+	}
+}
+
+func (pq *PriorityQueue[T]) contains(item *Item[T]) bool {
+	return item != nil && item.index >= 0 && item.index < len(pq.items) && pq.items[item.index] == item
+}
+
+func (pq *PriorityQueue[T]) removeLast() *Item[T] {
+	n := len(pq.items) - 1
+	item := pq.items[n]
+	pq.items[n] = nil
+	pq.items = pq.items[:n]
+	item.index = -1
+	return item
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i].Value, pq.items[parent].Value) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the value at index i down within the first n values, reporting whether it moved.
+func (pq *PriorityQueue[T]) down(i, n int) bool {
+	start := i
+	for {
+		child := 2*i + 1
+		if child >= n || child < 0 {
+			break
+		}
+		if right := child + 1; right < n && pq.less(pq.items[right].Value, pq.items[child].Value) {
+			child = right
+		}
+		if !pq.less(pq.items[child].Value, pq.items[i].Value) {
+			break
+		}
+		pq.swap(i, child)
+		i = child
+	}
+	return i > start
+}
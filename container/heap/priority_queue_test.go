@@ -0,0 +1,94 @@
+package heapext
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+func intLess(i, j int) bool {
+	return i < j
+}
+
+func TestPriorityQueue(t *testing.T) {
+	pq := NewPriorityQueue(intLess)
+	Equal(t, pq.Pop(), optionext.None[int]())
+	Equal(t, pq.Peek(), optionext.None[int]())
+
+	values := rand.Perm(100)
+	for _, v := range values {
+		pq.Push(v)
+	}
+	Equal(t, pq.Len(), 100)
+	Equal(t, pq.Peek(), optionext.Some(0))
+
+	var popped []int
+	for v := pq.Pop(); v.IsSome(); v = pq.Pop() {
+		popped = append(popped, v.Unwrap())
+	}
+	slices.Sort(values)
+	Equal(t, popped, values)
+	Equal(t, pq.Len(), 0)
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	pq := NewPriorityQueue(intLess)
+	items := make([]*Item[int], 10)
+	for i := range items {
+		items[i] = pq.Push(i * 10)
+	}
+
+	Equal(t, pq.Update(items[5], -1), true)
+	Equal(t, pq.Peek(), optionext.Some(-1))
+	Equal(t, pq.Update(items[5], 55), true)
+	Equal(t, pq.Peek(), optionext.Some(0))
+
+	Equal(t, pq.Remove(items[0]), true)
+	Equal(t, items[0].Index(), -1)
+	Equal(t, pq.Remove(items[0]), false)
+	Equal(t, pq.Update(items[0], 1), false)
+	Equal(t, pq.Fix(items[0]), false)
+	Equal(t, pq.Remove(nil), false)
+
+	// change in place then fix
+	items[9].Value = 5
+	Equal(t, pq.Fix(items[9]), true)
+	Equal(t, pq.Peek(), optionext.Some(5))
+	items[9].Value = 100
+	pq.FixAt(items[9].Index())
+
+	Equal(t, slices.Collect(pq.Drain()), []int{10, 20, 30, 40, 55, 60, 70, 80, 100})
+	Equal(t, pq.Len(), 0)
+	Equal(t, items[1].Index(), -1)
+
+	pq.Push(1)
+	item := pq.Push(2)
+	pq.Clear()
+	Equal(t, pq.Len(), 0)
+	Equal(t, pq.Remove(item), false)
+}
+
+func TestPriorityQueueBound(t *testing.T) {
+	pq := NewPriorityQueue(intLess).Bound(3)
+	for _, v := range []int{5, 1, 9, 3, 7, 2, 8} {
+		pq.Push(v)
+	}
+	Equal(t, pq.Len(), 3)
+	Equal(t, pq.Push(0), (*Item[int])(nil))
+
+	item := pq.Push(10)
+	NotEqual(t, item, nil)
+	Equal(t, item.Index() >= 0, true)
+
+	// drain stops early leaving the rest in the queue
+	for v := range pq.Drain() {
+		Equal(t, v, 8)
+		break
+	}
+	Equal(t, slices.Collect(pq.Drain()), []int{9, 10})
+
+	PanicMatches(t, func() { NewPriorityQueue(intLess).Bound(0) }, "heapext: bound must be positive")
+}
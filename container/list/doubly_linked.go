@@ -1,5 +1,7 @@
 package listext
 
+import "iter"
+
 // Node is an element of the doubly linked list.
 type Node[V any] struct {
 	next  *Node[V]
@@ -275,3 +277,33 @@ func (d *DoublyLinkedList[V]) Clear() {
 	}
 	d.head, d.tail, d.len = nil, nil, 0
 }
+
+// All returns an iterator over the list values from front to back.
+//
+// It is safe to remove the current node while iterating.
+func (d *DoublyLinkedList[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for node := d.head; node != nil; {
+			next := node.next
+			if !yield(node.Value) {
+				return
+			}
+			node = next
+		}
+	}
+}
+
+// Backward returns an iterator over the list values from back to front.
+//
+// It is safe to remove the current node while iterating.
+func (d *DoublyLinkedList[V]) Backward() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for node := d.tail; node != nil; {
+			prev := node.prev
+			if !yield(node.Value) {
+				return
+			}
+			node = prev
+		}
+	}
+}
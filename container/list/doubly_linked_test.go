@@ -401,6 +401,46 @@ func TestLinkedListMoving(t *testing.T) {
 	Equal(t, l.Len(), 0)
 }
 
+func TestIterators(t *testing.T) {
+	l := NewDoublyLinked[int]()
+	l.PushBack(1)
+	l.PushBack(2)
+	l.PushBack(3)
+
+	var values []int
+	for v := range l.All() {
+		values = append(values, v)
+	}
+	Equal(t, values, []int{1, 2, 3})
+
+	values = values[:0]
+	for v := range l.Backward() {
+		values = append(values, v)
+	}
+	Equal(t, values, []int{3, 2, 1})
+
+	values = values[:0]
+	for v := range l.All() {
+		if v == 2 {
+			break
+		}
+		values = append(values, v)
+	}
+	Equal(t, values, []int{1})
+
+	// removing the current node while iterating
+	values = values[:0]
+	node := l.Front()
+	for v := range l.All() {
+		next := node.Next()
+		l.Remove(node)
+		node = next
+		values = append(values, v)
+	}
+	Equal(t, values, []int{1, 2, 3})
+	Equal(t, l.Len(), 0)
+}
+
 func BenchmarkDoublyLinkedList_STD(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		l := list.New()
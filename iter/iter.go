@@ -0,0 +1,278 @@
+package iterext
+
+import (
+	"iter"
+
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// Map lazily maps an iter.Seq[T] -> iter.Seq[U] using the map function.
+func Map[T, U any](seq iter.Seq[T], fn func(v T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Map2 lazily maps an iter.Seq2[K, V] -> iter.Seq2[K2, V2] using the map function.
+func Map2[K, V, K2, V2 any](seq iter.Seq2[K, V], fn func(k K, v V) (K2, V2)) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range seq {
+			if !yield(fn(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter lazily filters out the elements specified by the function.
+//
+// This matches the behaviour of sliceext.Filter, use Retain to keep the specified elements instead.
+func Filter[T any](seq iter.Seq[T], fn func(v T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if fn(v) {
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Filter2 lazily filters out the elements specified by the function.
+func Filter2[K, V any](seq iter.Seq2[K, V], fn func(k K, v V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if fn(k, v) {
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Retain lazily retains only the elements specified by the function.
+func Retain[T any](seq iter.Seq[T], fn func(v T) bool) iter.Seq[T] {
+	return Filter(seq, func(v T) bool {
+		return !fn(v)
+	})
+}
+
+// Retain2 lazily retains only the elements specified by the function.
+func Retain2[K, V any](seq iter.Seq2[K, V], fn func(k K, v V) bool) iter.Seq2[K, V] {
+	return Filter2(seq, func(k K, v V) bool {
+		return !fn(k, v)
+	})
+}
+
+// Take yields at most the first n elements of the sequence.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			if i++; i == n {
+				return
+			}
+		}
+	}
+}
+
+// Take2 yields at most the first n elements of the sequence.
+func Take2[K, V any](seq iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+		for k, v := range seq {
+			if !yield(k, v) {
+				return
+			}
+			if i++; i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip skips the first n elements of the sequence and yields the rest.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Skip2 skips the first n elements of the sequence and yields the rest.
+func Skip2[K, V any](seq iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		i := 0
+		for k, v := range seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk yields consecutive chunks of up to size elements, the last chunk may contain fewer elements.
+//
+// Each chunk is a newly allocated slice which is safe to retain.
+// Chunk panics if size is less than 1.
+func Chunk[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("iterext.Chunk: size cannot be less than 1")
+	}
+
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Window yields overlapping windows of exactly size consecutive elements,
+// sliding forward by one element at a time.
+//
+// Nothing is yielded if the sequence contains fewer than size elements.
+// Each window is a newly allocated slice which is safe to retain.
+// Window panics if size is less than 1.
+func Window[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("iterext.Window: size cannot be less than 1")
+	}
+
+	return func(yield func([]T) bool) {
+		window := make([]T, 0, size)
+		for v := range seq {
+			if len(window) == size {
+				window = append(window[:0:0], window[1:]...)
+			}
+			window = append(window, v)
+			if len(window) == size {
+				if !yield(window) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip yields pairs of elements from both sequences until either is exhausted.
+func Zip[T, U any](a iter.Seq[T], b iter.Seq[U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		for v1 := range a {
+			v2, ok := next()
+			if !ok || !yield(v1, v2) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate yields each element of the sequence along with its index.
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range seq {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Keys yields the keys of the sequence.
+func Keys[K, V any](seq iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range seq {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values yields the values of the sequence.
+func Values[K, V any](seq iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range seq {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Reduce reduces the elements to a single one,
+// by repeatedly applying a reducing function.
+//
+// None is returned if the sequence is empty.
+func Reduce[T any](seq iter.Seq[T], fn func(accum T, current T) T) optionext.Option[T] {
+	var accum T
+	var found bool
+	for v := range seq {
+		if !found {
+			accum = v
+			found = true
+			continue
+		}
+		accum = fn(accum, v)
+	}
+
+	if !found {
+		return optionext.None[T]()
+	}
+	return optionext.Some(accum)
+}
+
+// Fold folds the elements into an accumulator starting with init,
+// by repeatedly applying the provided function.
+func Fold[T, U any](seq iter.Seq[T], init U, fn func(accum U, v T) U) U {
+	accum := init
+	for v := range seq {
+		accum = fn(accum, v)
+	}
+	return accum
+}
@@ -0,0 +1,112 @@
+package iterext
+
+import (
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+func collect2[K, V any](seq iter.Seq2[K, V]) (keys []K, values []V) {
+	for k, v := range seq {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	return
+}
+
+func TestMap(t *testing.T) {
+	s := slices.Collect(Map(slices.Values([]int{1, 2, 3}), strconv.Itoa))
+	Equal(t, s, []string{"1", "2", "3"})
+
+	keys, values := collect2(Map2(slices.All([]string{"a", "b"}), func(i int, v string) (string, int) {
+		return v, i
+	}))
+	Equal(t, keys, []string{"a", "b"})
+	Equal(t, values, []int{0, 1})
+}
+
+func TestFilterRetain(t *testing.T) {
+	seq := slices.Values([]int{0, 1, 2, 3})
+	even := func(v int) bool { return v%2 == 0 }
+	Equal(t, slices.Collect(Filter(seq, even)), []int{1, 3})
+	Equal(t, slices.Collect(Retain(seq, even)), []int{0, 2})
+
+	seq2 := slices.All([]int{0, 1, 2, 3})
+	_, values := collect2(Filter2(seq2, func(i, _ int) bool { return i > 1 }))
+	Equal(t, values, []int{0, 1})
+	_, values = collect2(Retain2(seq2, func(i, _ int) bool { return i > 1 }))
+	Equal(t, values, []int{2, 3})
+}
+
+func TestTakeSkip(t *testing.T) {
+	seq := slices.Values([]int{0, 1, 2, 3})
+	Equal(t, slices.Collect(Take(seq, 2)), []int{0, 1})
+	Equal(t, len(slices.Collect(Take(seq, 0))), 0)
+	Equal(t, slices.Collect(Take(seq, 10)), []int{0, 1, 2, 3})
+	Equal(t, slices.Collect(Skip(seq, 3)), []int{3})
+	Equal(t, len(slices.Collect(Skip(seq, 10))), 0)
+
+	// ensure laziness, Take must not pull more than required
+	var pulled int
+	counting := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	Equal(t, slices.Collect(Take(counting, 3)), []int{0, 1, 2})
+	Equal(t, pulled, 3)
+
+	seq2 := slices.All([]string{"a", "b", "c"})
+	keys, _ := collect2(Take2(seq2, 2))
+	Equal(t, keys, []int{0, 1})
+	keys, _ = collect2(Skip2(seq2, 2))
+	Equal(t, keys, []int{2})
+}
+
+func TestChunk(t *testing.T) {
+	chunks := slices.Collect(Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2))
+	Equal(t, chunks, [][]int{{1, 2}, {3, 4}, {5}})
+	Equal(t, len(slices.Collect(Chunk(slices.Values([]int{}), 2))), 0)
+	PanicMatches(t, func() { Chunk(slices.Values([]int{}), 0) }, "iterext.Chunk: size cannot be less than 1")
+}
+
+func TestWindow(t *testing.T) {
+	windows := slices.Collect(Window(slices.Values([]int{1, 2, 3, 4}), 3))
+	Equal(t, windows, [][]int{{1, 2, 3}, {2, 3, 4}})
+	Equal(t, len(slices.Collect(Window(slices.Values([]int{1, 2}), 3))), 0)
+	PanicMatches(t, func() { Window(slices.Values([]int{}), 0) }, "iterext.Window: size cannot be less than 1")
+}
+
+func TestZip(t *testing.T) {
+	keys, values := collect2(Zip(slices.Values([]int{1, 2, 3}), slices.Values([]string{"a", "b"})))
+	Equal(t, keys, []int{1, 2})
+	Equal(t, values, []string{"a", "b"})
+}
+
+func TestEnumerate(t *testing.T) {
+	keys, values := collect2(Enumerate(slices.Values([]string{"a", "b"})))
+	Equal(t, keys, []int{0, 1})
+	Equal(t, values, []string{"a", "b"})
+
+	seq2 := Enumerate(slices.Values([]string{"a", "b"}))
+	Equal(t, slices.Collect(Keys(seq2)), []int{0, 1})
+	Equal(t, slices.Collect(Values(seq2)), []string{"a", "b"})
+}
+
+func TestReduceFold(t *testing.T) {
+	sum := func(accum, current int) int { return accum + current }
+	Equal(t, Reduce(slices.Values([]int{1, 2, 3}), sum), optionext.Some(6))
+	Equal(t, Reduce(slices.Values([]int{}), sum), optionext.None[int]())
+
+	s := Fold(slices.Values([]int{1, 2}), "", func(accum string, v int) string {
+		return accum + strconv.Itoa(v)
+	})
+	Equal(t, s, "12")
+}
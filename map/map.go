@@ -1,5 +1,10 @@
 package mapext
 
+import (
+	"iter"
+	"maps"
+)
+
 // Map allows mapping of a map[K]V -> U.
 func Map[K comparable, V any, U any](m map[K]V, init U, fn func(accum U, key K, value V) U) U {
 	accum := init
@@ -19,3 +24,25 @@ func Retain[K comparable, V any](m map[K]V, fn func(key K, value V) bool) {
 		delete(m, k)
 	}
 }
+
+// All returns an iterator over the key-value pairs of the map.
+//
+// The iteration order is not specified and is not guaranteed to be the same from one call to the next.
+func All[K comparable, V any](m map[K]V) iter.Seq2[K, V] {
+	return maps.All(m)
+}
+
+// Keys returns an iterator over the keys of the map.
+func Keys[K comparable, V any](m map[K]V) iter.Seq[K] {
+	return maps.Keys(m)
+}
+
+// Values returns an iterator over the values of the map.
+func Values[K comparable, V any](m map[K]V) iter.Seq[V] {
+	return maps.Values(m)
+}
+
+// Collect collects the key-value pairs from the iterator into a new map.
+func Collect[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	return maps.Collect(seq)
+}
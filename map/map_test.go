@@ -45,3 +45,22 @@ func TestRetain(t *testing.T) {
 	Equal(t, m["0"], 0)
 	Equal(t, m["3"], 3)
 }
+
+func TestIterators(t *testing.T) {
+	m := map[string]int{"0": 0, "1": 1}
+	Equal(t, Collect(All(m)), m)
+
+	keys := make([]string, 0, len(m))
+	for k := range Keys(m) {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	Equal(t, keys, []string{"0", "1"})
+
+	values := make([]int, 0, len(m))
+	for v := range Values(m) {
+		values = append(values, v)
+	}
+	sort.Ints(values)
+	Equal(t, values, []int{0, 1})
+}
@@ -0,0 +1,394 @@
+package httpext
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	bytesext "github.com/pchchv/extender/bytes"
+	listext "github.com/pchchv/extender/container/list"
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// cacheableStatusCodes defines the response codes that are cacheable by default, RFC 9110 Section 15.1.
+var cacheableStatusCodes = map[int]bool{
+	http.StatusOK:                   true,
+	http.StatusNonAuthoritativeInfo: true,
+	http.StatusNoContent:            true,
+	http.StatusMultipleChoices:      true,
+	http.StatusMovedPermanently:     true,
+	http.StatusPermanentRedirect:    true,
+	http.StatusNotFound:             true,
+	http.StatusMethodNotAllowed:     true,
+	http.StatusGone:                 true,
+	http.StatusRequestURITooLong:    true,
+	http.StatusNotImplemented:       true,
+}
+
+// CachedResponse is a stored HTTP response.
+//
+// It is exported to allow custom `CacheStore` implementations to serialize it.
+type CachedResponse struct {
+	StatusCode   int         // the HTTP response status code
+	Header       http.Header // the HTTP response headers
+	Body         []byte      // the HTTP response body
+	VaryHeader   http.Header // the request header values selected by the responses Vary header
+	RequestTime  time.Time   // the time the request that produced the response was sent
+	ResponseTime time.Time   // the time the response was received
+}
+
+// CacheStore is used to store cached HTTP responses by key.
+//
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	Get(key string) optionext.Option[CachedResponse]
+	Set(key string, resp CachedResponse)
+	Delete(key string)
+}
+
+// CacheTransport is an `http.RoundTripper` implementing a private client side HTTP cache per RFC 9111.
+//
+// It supports:
+//   - Freshness via `Cache-Control: max-age`, `Expires` and a heuristic based on `Last-Modified`.
+//   - `no-store`, `no-cache` and `must-revalidate` request and response directives.
+//   - Revalidation using `ETag` and `Last-Modified` validators.
+//   - `Vary` by storing the selecting request headers alongside the response.
+//   - Invalidation upon successful unsafe requests to the same URL.
+//
+// Only GET requests are cached, requests with a `Range` or conditional headers are passed through untouched.
+// When revalidation fails due to a transport error a stale response is served,
+// unless disallowed by `must-revalidate` or `no-cache`.
+//
+// The `CacheTransport` is designed to be used with the `Retryer` eg.
+// `NewRetryer().Client(&http.Client{Transport: NewCacheTransport()})`.
+type CacheTransport struct {
+	transport http.RoundTripper
+	store     CacheStore
+	maxBytes  bytesext.Bytes
+}
+
+// NewCacheTransport returns a new `CacheTransport` with sane default values.
+//
+// The default values are:
+//   - `Transport` is `http.DefaultTransport`.
+//   - `Store` is an in-memory LRU `CacheStore` holding up to 1000 responses.
+//   - `MaxBytes` is 2MiB, larger responses are not cached.
+func NewCacheTransport() CacheTransport {
+	return CacheTransport{
+		transport: http.DefaultTransport,
+		store:     NewMemoryCacheStore(1000),
+		maxBytes:  2 * bytesext.MiB,
+	}
+}
+
+// Transport sets the underlying `http.RoundTripper` used to make requests.
+func (c CacheTransport) Transport(transport http.RoundTripper) CacheTransport {
+	c.transport = transport
+	return c
+}
+
+// Store sets the `CacheStore` used to store responses.
+func (c CacheTransport) Store(store CacheStore) CacheTransport {
+	c.store = store
+	return c
+}
+
+// MaxBytes sets the maximum response body size which will be cached.
+func (c CacheTransport) MaxBytes(maxBytes bytesext.Bytes) CacheTransport {
+	c.maxBytes = maxBytes
+	return c
+}
+
+// RoundTrip implements the `http.RoundTripper` interface.
+func (c CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.URL.String()
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		resp, err := c.transport.RoundTrip(req)
+		if err == nil && resp.StatusCode < 400 && req.Method != http.MethodOptions && req.Method != http.MethodTrace {
+			c.store.Delete(key)
+		}
+		return resp, err
+	}
+
+	reqCC := parseCacheControl(req.Header)
+	if req.Method != http.MethodGet || req.Header.Get(Range) != "" || hasConditionalHeaders(req.Header) {
+		return c.transport.RoundTrip(req)
+	}
+
+	_, noStore := reqCC["no-store"]
+	opt := c.store.Get(key)
+	if noStore || opt.IsNone() || !opt.Unwrap().matchesVary(req.Header) {
+		return c.roundTrip(req, key, noStore)
+	}
+
+	cached := opt.Unwrap()
+	now := time.Now()
+	respCC := parseCacheControl(cached.Header)
+	age := cached.age(now)
+	fresh := age < cached.lifetime(respCC)
+	if maxAge, ok := reqCC["max-age"]; ok {
+		if secs, err := strconv.ParseInt(maxAge, 10, 64); err == nil && age > time.Duration(secs)*time.Second {
+			fresh = false
+		}
+	}
+	_, reqNoCache := reqCC["no-cache"]
+	_, respNoCache := respCC["no-cache"]
+	if fresh && !reqNoCache && !respNoCache {
+		return cached.response(req, age), nil
+	}
+
+	// stale or revalidation required
+	conditional := req.Clone(req.Context())
+	if etag := cached.Header.Get(ETag); etag != "" {
+		conditional.Header.Set(IfNoneMatch, etag)
+	}
+	if lm := cached.Header.Get(LastModified); lm != "" {
+		conditional.Header.Set(IfModifiedSince, lm)
+	}
+
+	requestTime := time.Now()
+	resp, err := c.transport.RoundTrip(conditional)
+	if err != nil {
+		_, mustRevalidate := respCC["must-revalidate"]
+		if mustRevalidate || reqNoCache || respNoCache {
+			return nil, err
+		}
+		return cached.response(req, cached.age(time.Now())), nil
+	}
+
+	if resp.StatusCode != http.StatusNotModified {
+		return c.storeResponse(req, key, resp, requestTime, false)
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, c.maxBytes))
+	_ = resp.Body.Close()
+	cached.Header = cached.Header.Clone()
+	for k, v := range resp.Header {
+		if k != ContentLength {
+			cached.Header[k] = v
+		}
+	}
+	cached.RequestTime, cached.ResponseTime = requestTime, time.Now()
+	c.store.Set(key, cached)
+	return cached.response(req, cached.age(time.Now())), nil
+}
+
+func (c CacheTransport) roundTrip(req *http.Request, key string, noStore bool) (*http.Response, error) {
+	requestTime := time.Now()
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.storeResponse(req, key, resp, requestTime, noStore)
+}
+
+// storeResponse stores the response, if cacheable, and returns it for use.
+func (c CacheTransport) storeResponse(req *http.Request, key string, resp *http.Response, requestTime time.Time, noStore bool) (*http.Response, error) {
+	cc := parseCacheControl(resp.Header)
+	if _, ok := cc["no-store"]; ok || noStore || !isCacheable(resp, cc) {
+		return resp, nil
+	}
+
+	b, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	if int64(len(b)) > c.maxBytes {
+		// too large to cache, stitch the already read bytes back onto the body
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(b), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+
+	cached := CachedResponse{
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         b,
+		VaryHeader:   make(http.Header),
+		RequestTime:  requestTime,
+		ResponseTime: time.Now(),
+	}
+	for _, field := range parseHeaderList(resp.Header.Values(Vary)) {
+		cached.VaryHeader[http.CanonicalHeaderKey(field)] = req.Header.Values(field)
+	}
+	c.store.Set(key, cached)
+	return resp, nil
+}
+
+func (c CachedResponse) response(req *http.Request, age time.Duration) *http.Response {
+	header := c.Header.Clone()
+	header.Set(Age, strconv.FormatInt(int64(age/time.Second), 10))
+	return &http.Response{
+		Status:        strconv.Itoa(c.StatusCode) + " " + http.StatusText(c.StatusCode),
+		StatusCode:    c.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.Body)),
+		ContentLength: int64(len(c.Body)),
+		Request:       req,
+	}
+}
+
+func (c CachedResponse) matchesVary(header http.Header) bool {
+	for field, values := range c.VaryHeader {
+		if strings.Join(header.Values(field), ",") != strings.Join(values, ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// age calculates the current age of the response, RFC 9111 Section 4.2.3.
+func (c CachedResponse) age(now time.Time) time.Duration {
+	var apparentAge time.Duration
+	if date, err := http.ParseTime(c.Header.Get(Date)); err == nil {
+		apparentAge = max(0, c.ResponseTime.Sub(date))
+	}
+
+	var ageValue time.Duration
+	if secs, err := strconv.ParseInt(c.Header.Get(Age), 10, 64); err == nil {
+		ageValue = time.Duration(secs) * time.Second
+	}
+
+	correctedAge := ageValue + c.ResponseTime.Sub(c.RequestTime)
+	return max(apparentAge, correctedAge) + now.Sub(c.ResponseTime)
+}
+
+// lifetime calculates the freshness lifetime of the response, RFC 9111 Section 4.2.1.
+func (c CachedResponse) lifetime(cc map[string]string) time.Duration {
+	if maxAge, ok := cc["max-age"]; ok {
+		if secs, err := strconv.ParseInt(maxAge, 10, 64); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+		return 0
+	}
+
+	date, err := http.ParseTime(c.Header.Get(Date))
+	if err != nil {
+		date = c.ResponseTime
+	}
+
+	if expires := c.Header.Get(Expires); expires != "" {
+		t, err := http.ParseTime(expires)
+		if err != nil {
+			return 0
+		}
+		return t.Sub(date)
+	}
+
+	// heuristic freshness of 10% of the time since last modified, RFC 9111 Section 4.2.2
+	if lm, err := http.ParseTime(c.Header.Get(LastModified)); err == nil && date.After(lm) {
+		return date.Sub(lm) / 10
+	}
+	return 0
+}
+
+func isCacheable(resp *http.Response, cc map[string]string) bool {
+	if !cacheableStatusCodes[resp.StatusCode] {
+		return false
+	}
+
+	for _, field := range parseHeaderList(resp.Header.Values(Vary)) {
+		if field == Any {
+			return false
+		}
+	}
+
+	_, hasMaxAge := cc["max-age"]
+	_, hasNoCache := cc["no-cache"]
+	return hasMaxAge || hasNoCache || resp.Header.Get(Expires) != "" ||
+		resp.Header.Get(ETag) != "" || resp.Header.Get(LastModified) != ""
+}
+
+func hasConditionalHeaders(header http.Header) bool {
+	return header.Get(IfNoneMatch) != "" || header.Get(IfModifiedSince) != "" ||
+		header.Get(IfMatch) != "" || header.Get(IfUnmodifiedSince) != "" || header.Get(IfRange) != ""
+}
+
+// parseCacheControl parses the Cache-Control header directives into a map of lowercase directive to its value.
+func parseCacheControl(header http.Header) map[string]string {
+	cc := make(map[string]string)
+	for _, value := range header.Values(CacheControl) {
+		for _, directive := range strings.Split(value, ",") {
+			directive = strings.TrimSpace(directive)
+			if directive == "" {
+				continue
+			}
+
+			k, v, _ := strings.Cut(directive, "=")
+			cc[strings.ToLower(strings.TrimSpace(k))] = strings.Trim(strings.TrimSpace(v), `"`)
+		}
+	}
+	return cc
+}
+
+type memoryCacheEntry struct {
+	key  string
+	resp CachedResponse
+}
+
+type memoryCacheStore struct {
+	m        sync.Mutex
+	list     *listext.DoublyLinkedList[memoryCacheEntry]
+	nodes    map[string]*listext.Node[memoryCacheEntry]
+	capacity int
+}
+
+// NewMemoryCacheStore returns a new in-memory LRU `CacheStore` holding up to capacity responses.
+func NewMemoryCacheStore(capacity int) CacheStore {
+	return &memoryCacheStore{
+		list:     listext.NewDoublyLinked[memoryCacheEntry](),
+		nodes:    make(map[string]*listext.Node[memoryCacheEntry]),
+		capacity: capacity,
+	}
+}
+
+func (s *memoryCacheStore) Get(key string) optionext.Option[CachedResponse] {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	node, found := s.nodes[key]
+	if !found {
+		return optionext.None[CachedResponse]()
+	}
+	s.list.MoveToFront(node)
+	return optionext.Some(node.Value.resp)
+}
+
+func (s *memoryCacheStore) Set(key string, resp CachedResponse) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if node, found := s.nodes[key]; found {
+		node.Value.resp = resp
+		s.list.MoveToFront(node)
+		return
+	}
+
+	s.nodes[key] = s.list.PushFront(memoryCacheEntry{key: key, resp: resp})
+	for s.list.Len() > s.capacity {
+		delete(s.nodes, s.list.PopBack().Value.key)
+	}
+}
+
+func (s *memoryCacheStore) Delete(key string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if node, found := s.nodes[key]; found {
+		s.list.Remove(node)
+		delete(s.nodes, key)
+	}
+}
@@ -0,0 +1,189 @@
+package httpext
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	resultext "github.com/pchchv/extender/values/result"
+	. "github.com/pchchv/go-assert"
+)
+
+func TestCacheTransport(t *testing.T) {
+	var hits, revalidations int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/max-age", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set(CacheControl, "max-age=60")
+		_, _ = w.Write([]byte("max-age"))
+	})
+	mux.HandleFunc("/no-store", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set(CacheControl, "no-store")
+		_, _ = w.Write([]byte("no-store"))
+	})
+	mux.HandleFunc("/etag", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set(CacheControl, "no-cache")
+		w.Header().Set(ETag, `"v1"`)
+		if r.Header.Get(IfNoneMatch) == `"v1"` {
+			atomic.AddInt32(&revalidations, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte("etag"))
+	})
+	mux.HandleFunc("/vary", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set(CacheControl, "max-age=60")
+		w.Header().Set(Vary, AcceptedLanguage)
+		_, _ = w.Write([]byte(r.Header.Get(AcceptedLanguage)))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := &http.Client{Transport: NewCacheTransport()}
+	get := func(t *testing.T, path string, headers ...string) (string, *http.Response) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		Equal(t, err, nil)
+		for i := 0; i < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+
+		resp, err := client.Do(req)
+		Equal(t, err, nil)
+		defer resp.Body.Close()
+
+		b, err := io.ReadAll(resp.Body)
+		Equal(t, err, nil)
+		return string(b), resp
+	}
+
+	t.Run("max-age", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		body, _ := get(t, "/max-age")
+		Equal(t, body, "max-age")
+		body, resp := get(t, "/max-age")
+		Equal(t, body, "max-age")
+		Equal(t, resp.Header.Get(Age), "0")
+		Equal(t, atomic.LoadInt32(&hits), int32(1))
+
+		// request no-cache must go to the origin
+		body, _ = get(t, "/max-age", CacheControl, "no-cache")
+		Equal(t, body, "max-age")
+		Equal(t, atomic.LoadInt32(&hits), int32(2))
+
+		// unsafe methods invalidate
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/max-age", nil)
+		resp, err := client.Do(req)
+		Equal(t, err, nil)
+		_ = resp.Body.Close()
+		Equal(t, atomic.LoadInt32(&hits), int32(3))
+		_, _ = get(t, "/max-age")
+		Equal(t, atomic.LoadInt32(&hits), int32(4))
+	})
+
+	t.Run("no-store", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		_, _ = get(t, "/no-store")
+		body, _ := get(t, "/no-store")
+		Equal(t, body, "no-store")
+		Equal(t, atomic.LoadInt32(&hits), int32(2))
+	})
+
+	t.Run("etag revalidation", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		_, _ = get(t, "/etag")
+		body, resp := get(t, "/etag")
+		Equal(t, body, "etag")
+		Equal(t, resp.StatusCode, http.StatusOK)
+		Equal(t, atomic.LoadInt32(&hits), int32(2))
+		Equal(t, atomic.LoadInt32(&revalidations), int32(1))
+	})
+
+	t.Run("vary", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		body, _ := get(t, "/vary", AcceptedLanguage, "en")
+		Equal(t, body, "en")
+		body, _ = get(t, "/vary", AcceptedLanguage, "en")
+		Equal(t, body, "en")
+		Equal(t, atomic.LoadInt32(&hits), int32(1))
+		body, _ = get(t, "/vary", AcceptedLanguage, "da")
+		Equal(t, body, "da")
+		Equal(t, atomic.LoadInt32(&hits), int32(2))
+	})
+}
+
+func TestCacheTransportWithRetryer(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set(CacheControl, "max-age=60")
+		_ = JSON(w, http.StatusOK, map[string]string{"name": "test"})
+	}))
+	defer server.Close()
+
+	retryer := NewRetryer().Client(&http.Client{Transport: NewCacheTransport()})
+	for i := 0; i < 3; i++ {
+		var v map[string]string
+		err := retryer.Do(context.Background(), func(ctx context.Context) resultext.Result[*http.Request, error] {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				return resultext.Err[*http.Request, error](err)
+			}
+			return resultext.Ok[*http.Request, error](req)
+		}, &v, http.StatusOK)
+		Equal(t, err, nil)
+		Equal(t, v["name"], "test")
+	}
+	Equal(t, atomic.LoadInt32(&hits), int32(1))
+}
+
+type errRoundTripper struct{}
+
+func (errRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestCacheTransportStaleOnError(t *testing.T) {
+	store := NewMemoryCacheStore(10)
+	stale := CachedResponse{
+		StatusCode:   http.StatusOK,
+		Header:       http.Header{CacheControl: []string{"max-age=1"}},
+		Body:         []byte("stale"),
+		RequestTime:  time.Now().Add(-time.Minute),
+		ResponseTime: time.Now().Add(-time.Minute),
+	}
+	store.Set("http://example.com/", stale)
+
+	transport := NewCacheTransport().Store(store).Transport(errRoundTripper{})
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	resp, err := transport.RoundTrip(req)
+	Equal(t, err, nil)
+	b, _ := io.ReadAll(resp.Body)
+	Equal(t, string(b), "stale")
+
+	stale.Header.Set(CacheControl, "max-age=1, must-revalidate")
+	store.Set("http://example.com/", stale)
+	_, err = transport.RoundTrip(req)
+	NotEqual(t, err, nil)
+}
+
+func TestMemoryCacheStore(t *testing.T) {
+	store := NewMemoryCacheStore(2)
+	store.Set("a", CachedResponse{StatusCode: 1})
+	store.Set("b", CachedResponse{StatusCode: 2})
+	Equal(t, store.Get("a").Unwrap().StatusCode, 1)
+	store.Set("c", CachedResponse{StatusCode: 3})
+	Equal(t, store.Get("b").IsNone(), true)
+	Equal(t, store.Get("a").IsSome(), true)
+	Equal(t, store.Get("c").IsSome(), true)
+	store.Delete("a")
+	Equal(t, store.Get("a").IsNone(), true)
+}
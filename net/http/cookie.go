@@ -0,0 +1,296 @@
+package httpext
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// maxCookieSize is the maximum size of an encoded cookie value browsers are guaranteed to accept.
+	maxCookieSize = 4096
+	// maxCookieClockSkew is how far in the future the issued time of a cookie value may be
+	// before it is rejected, allowing for clock differences between servers sharing keys.
+	maxCookieClockSkew = time.Minute
+)
+
+var (
+	// ErrInvalidCookie is returned when a cookie value could not be verified or decrypted with any of the keys.
+	ErrInvalidCookie = errors.New("invalid cookie value")
+	// ErrCookieExpired is returned when a cookie value is older than the configured max age.
+	ErrCookieExpired = errors.New("cookie value expired")
+	// ErrCookieTooLong is returned when an encoded cookie value exceeds 4096 bytes.
+	ErrCookieTooLong = errors.New("cookie value too long")
+	// ErrNoCookieKeys is returned when attempting to create or use a CookieCodec without any keys.
+	ErrNoCookieKeys = errors.New("at least one cookie key is required")
+)
+
+// CookieCodec signs or encrypts cookie values.
+//
+// The first key is used to sign/encrypt new values while all keys are tried,
+// in order, when decoding allowing keys to be rotated without invalidating existing cookies.
+//
+// The cookie name is bound to the signature so that values cannot be swapped between cookies
+// and the time the value was issued is included to allow enforcing a max age.
+type CookieCodec struct {
+	keys   [][]byte
+	aeads  []cipher.AEAD
+	maxAge time.Duration
+	now    func() time.Time
+}
+
+// NewSignedCookieCodec returns a new `CookieCodec` which signs, but does not encrypt,
+// cookie values using HMAC-SHA256.
+func NewSignedCookieCodec(keys ...[]byte) (CookieCodec, error) {
+	if len(keys) == 0 {
+		return CookieCodec{}, ErrNoCookieKeys
+	}
+	return CookieCodec{keys: keys}, nil
+}
+
+// NewEncryptedCookieCodec returns a new `CookieCodec` which encrypts and authenticates
+// cookie values using AES-GCM.
+//
+// Each key must be 16, 24 or 32 bytes to select AES-128, AES-192 or AES-256.
+func NewEncryptedCookieCodec(keys ...[]byte) (CookieCodec, error) {
+	if len(keys) == 0 {
+		return CookieCodec{}, ErrNoCookieKeys
+	}
+
+	aeads := make([]cipher.AEAD, 0, len(keys))
+	for _, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return CookieCodec{}, err
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return CookieCodec{}, err
+		}
+		aeads = append(aeads, aead)
+	}
+	return CookieCodec{aeads: aeads}, nil
+}
+
+// MaxAge sets the maximum age of a cookie value, since it was encoded, that will be accepted when decoding.
+//
+// A max age of 0 disables the check and is the default.
+func (c CookieCodec) MaxAge(maxAge time.Duration) CookieCodec {
+	c.maxAge = maxAge
+	return c
+}
+
+// Encode signs or encrypts the value for the named cookie and returns the cookie safe encoded value.
+//
+// A zero value `CookieCodec` has no keys and returns `ErrNoCookieKeys`.
+func (c CookieCodec) Encode(name string, value []byte) (string, error) {
+	if len(c.aeads) == 0 && len(c.keys) == 0 {
+		return "", ErrNoCookieKeys
+	}
+
+	payload := make([]byte, 8, 8+len(value))
+	binary.BigEndian.PutUint64(payload, uint64(c.timeNow().Unix()))
+	payload = append(payload, value...)
+
+	var b []byte
+	if len(c.aeads) > 0 {
+		aead := c.aeads[0]
+		nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(payload)+aead.Overhead())
+		if _, err := rand.Read(nonce); err != nil {
+			return "", err
+		}
+		b = aead.Seal(nonce, nonce, payload, []byte(name))
+	} else {
+		b = append(payload, cookieMAC(c.keys[0], name, payload)...)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(b)
+	if len(encoded) > maxCookieSize {
+		return "", ErrCookieTooLong
+	}
+	return encoded, nil
+}
+
+// Decode verifies or decrypts the encoded value of the named cookie and returns the original value.
+//
+// Values issued further in the future than a small allowance for clock skew are rejected as invalid.
+func (c CookieCodec) Decode(name, value string) ([]byte, error) {
+	if len(value) > maxCookieSize {
+		return nil, ErrCookieTooLong
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCookie
+	}
+
+	var payload []byte
+	if c.aeads != nil {
+		for _, aead := range c.aeads {
+			if len(b) < aead.NonceSize() {
+				continue
+			}
+			nonce, sealed := b[:aead.NonceSize()], b[aead.NonceSize():]
+			if payload, err = aead.Open(nil, nonce, sealed, []byte(name)); err == nil {
+				break
+			}
+			payload = nil
+		}
+	} else if len(b) >= 8+sha256.Size {
+		data, mac := b[:len(b)-sha256.Size], b[len(b)-sha256.Size:]
+		for _, key := range c.keys {
+			if hmac.Equal(mac, cookieMAC(key, name, data)) {
+				payload = data
+				break
+			}
+		}
+	}
+
+	if len(payload) < 8 {
+		return nil, ErrInvalidCookie
+	}
+
+	now := c.timeNow()
+	issued := time.Unix(int64(binary.BigEndian.Uint64(payload[:8])), 0)
+	if issued.Sub(now) > maxCookieClockSkew {
+		return nil, ErrInvalidCookie
+	}
+	if c.maxAge > 0 && now.Sub(issued) > c.maxAge {
+		return nil, ErrCookieExpired
+	}
+	return payload[8:], nil
+}
+
+// SetCookie encodes the cookies value and adds it to the response headers.
+//
+// The provided cookie is left unchanged.
+func (c CookieCodec) SetCookie(w http.ResponseWriter, cookie *http.Cookie) error {
+	encoded, err := c.Encode(cookie.Name, []byte(cookie.Value))
+	if err != nil {
+		return err
+	}
+
+	encodedCookie := *cookie
+	encodedCookie.Value = encoded
+	http.SetCookie(w, &encodedCookie)
+	return nil
+}
+
+// Cookie returns the decoded value of the named cookie from the request.
+//
+// If the cookie is not found `http.ErrNoCookie` is returned.
+func (c CookieCodec) Cookie(r *http.Request, name string) (string, error) {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := c.Decode(name, cookie.Value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// SetJSON marshals the provided value to JSON and sets it as the value of a copy of the provided cookie.
+func (c CookieCodec) SetJSON(w http.ResponseWriter, cookie *http.Cookie, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	jsonCookie := *cookie
+	jsonCookie.Value = string(b)
+	return c.SetCookie(w, &jsonCookie)
+}
+
+// DecodeJSON decodes the JSON value of the named cookie from the request into the provided value.
+func (c CookieCodec) DecodeJSON(r *http.Request, name string, v interface{}) error {
+	value, err := c.Cookie(r, name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(value), v)
+}
+
+// SetForm encodes the provided value using the `DefaultFormEncoder` and sets it as the value of a copy of the provided cookie.
+func (c CookieCodec) SetForm(w http.ResponseWriter, cookie *http.Cookie, v interface{}) error {
+	values, err := DefaultFormEncoder.Encode(v)
+	if err != nil {
+		return err
+	}
+
+	formCookie := *cookie
+	formCookie.Value = values.Encode()
+	return c.SetCookie(w, &formCookie)
+}
+
+// DecodeForm decodes the form encoded value of the named cookie from the request into
+// the provided value using the `DefaultFormDecoder`.
+func (c CookieCodec) DecodeForm(r *http.Request, name string, v interface{}) error {
+	value, err := c.Cookie(r, name)
+	if err != nil {
+		return err
+	}
+
+	values, err := url.ParseQuery(value)
+	if err != nil {
+		return err
+	}
+	return DefaultFormDecoder.Decode(v, values)
+}
+
+// NewCookie returns a new cookie with secure defaults.
+//
+// The defaults are:
+//   - `Path` is "/".
+//   - `HttpOnly` is true.
+//   - `Secure` is true.
+//   - `SameSite` is `http.SameSiteLaxMode`.
+func NewCookie(name, value string) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// DeleteCookie instructs the client to remove the provided cookie.
+//
+// The cookie `Path` and `Domain` must match those used when the cookie was set.
+func DeleteCookie(w http.ResponseWriter, cookie *http.Cookie) {
+	c := *cookie
+	c.Value = ""
+	c.MaxAge = -1
+	c.Expires = time.Unix(1, 0)
+	http.SetCookie(w, &c)
+}
+
+// timeNow returns the current time, which can be overridden in tests.
+func (c CookieCodec) timeNow() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+func cookieMAC(key []byte, name string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(name))
+	mac.Write([]byte{'|'})
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
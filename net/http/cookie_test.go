@@ -0,0 +1,166 @@
+package httpext
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestCookieCodec(t *testing.T) {
+	signed, err := NewSignedCookieCodec([]byte("secret"))
+	Equal(t, err, nil)
+	encrypted, err := NewEncryptedCookieCodec([]byte("0123456789abcdef"))
+	Equal(t, err, nil)
+
+	tests := []struct {
+		name  string
+		codec CookieCodec
+	}{
+		{name: "signed", codec: signed},
+		{name: "encrypted", codec: encrypted},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := tc.codec.Encode("session", []byte("value"))
+			Equal(t, err, nil)
+
+			b, err := tc.codec.Decode("session", encoded)
+			Equal(t, err, nil)
+			Equal(t, string(b), "value")
+
+			// value must be bound to the cookie name
+			_, err = tc.codec.Decode("other", encoded)
+			Equal(t, err, ErrInvalidCookie)
+
+			_, err = tc.codec.Decode("session", encoded[:len(encoded)-2]+"AA")
+			Equal(t, err, ErrInvalidCookie)
+
+			_, err = tc.codec.Decode("session", "!!!")
+			Equal(t, err, ErrInvalidCookie)
+		})
+	}
+}
+
+func TestCookieCodecKeyRotation(t *testing.T) {
+	old, err := NewEncryptedCookieCodec([]byte("0123456789abcdef"))
+	Equal(t, err, nil)
+	rotated, err := NewEncryptedCookieCodec([]byte("fedcba9876543210"), []byte("0123456789abcdef"))
+	Equal(t, err, nil)
+
+	encoded, err := old.Encode("session", []byte("value"))
+	Equal(t, err, nil)
+
+	b, err := rotated.Decode("session", encoded)
+	Equal(t, err, nil)
+	Equal(t, string(b), "value")
+
+	encoded, err = rotated.Encode("session", []byte("value"))
+	Equal(t, err, nil)
+	_, err = old.Decode("session", encoded)
+	Equal(t, err, ErrInvalidCookie)
+
+	signedOld, _ := NewSignedCookieCodec([]byte("old"))
+	signedNew, _ := NewSignedCookieCodec([]byte("new"), []byte("old"))
+	encoded, err = signedOld.Encode("session", []byte("value"))
+	Equal(t, err, nil)
+	b, err = signedNew.Decode("session", encoded)
+	Equal(t, err, nil)
+	Equal(t, string(b), "value")
+}
+
+func TestCookieCodecErrors(t *testing.T) {
+	_, err := NewSignedCookieCodec()
+	Equal(t, err, ErrNoCookieKeys)
+
+	_, err = NewEncryptedCookieCodec([]byte("short"))
+	NotEqual(t, err, nil)
+
+	codec, _ := NewSignedCookieCodec([]byte("secret"))
+	_, err = codec.Encode("session", make([]byte, maxCookieSize))
+	Equal(t, err, ErrCookieTooLong)
+
+	_, err = CookieCodec{}.Encode("session", []byte("value"))
+	Equal(t, err, ErrNoCookieKeys)
+	_, err = CookieCodec{}.Decode("session", "value")
+	Equal(t, err, ErrInvalidCookie)
+
+	issued := time.Now()
+	codec.now = func() time.Time { return issued }
+	encoded, err := codec.Encode("session", []byte("value"))
+	Equal(t, err, nil)
+
+	codec.now = func() time.Time { return issued.Add(2 * time.Second) }
+	_, err = codec.MaxAge(time.Second).Decode("session", encoded)
+	Equal(t, err, ErrCookieExpired)
+	_, err = codec.MaxAge(time.Hour).Decode("session", encoded)
+	Equal(t, err, nil)
+
+	// issued in the future beyond the allowed clock skew
+	codec.now = func() time.Time { return issued.Add(-maxCookieClockSkew - 2*time.Second) }
+	_, err = codec.Decode("session", encoded)
+	Equal(t, err, ErrInvalidCookie)
+	codec.now = func() time.Time { return issued.Add(-maxCookieClockSkew + 2*time.Second) }
+	_, err = codec.Decode("session", encoded)
+	Equal(t, err, nil)
+}
+
+func TestCookieCodecHelpers(t *testing.T) {
+	type session struct {
+		ID   int    `json:"id" form:"id"`
+		Name string `json:"name" form:"name"`
+	}
+
+	codec, err := NewEncryptedCookieCodec([]byte("0123456789abcdef"))
+	Equal(t, err, nil)
+
+	w := httptest.NewRecorder()
+	plain := NewCookie("plain", "value")
+	Equal(t, codec.SetCookie(w, plain), nil)
+	Equal(t, plain.Value, "value")
+	jsonCookie := NewCookie("json", "")
+	Equal(t, codec.SetJSON(w, jsonCookie, session{ID: 1, Name: "joeybloggs"}), nil)
+	Equal(t, jsonCookie.Value, "")
+	formCookie := NewCookie("form", "")
+	Equal(t, codec.SetForm(w, formCookie, session{ID: 2, Name: "joeybloggs"}), nil)
+	Equal(t, formCookie.Value, "")
+
+	cookies := w.Result().Cookies()
+	Equal(t, len(cookies), 3)
+	for _, c := range cookies {
+		Equal(t, c.HttpOnly, true)
+		Equal(t, c.Secure, true)
+		Equal(t, c.SameSite, http.SameSiteLaxMode)
+		Equal(t, c.Path, "/")
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, c := range cookies {
+		r.AddCookie(c)
+	}
+
+	value, err := codec.Cookie(r, "plain")
+	Equal(t, err, nil)
+	Equal(t, value, "value")
+
+	var s session
+	Equal(t, codec.DecodeJSON(r, "json", &s), nil)
+	Equal(t, s, session{ID: 1, Name: "joeybloggs"})
+
+	s = session{}
+	Equal(t, codec.DecodeForm(r, "form", &s), nil)
+	Equal(t, s, session{ID: 2, Name: "joeybloggs"})
+
+	_, err = codec.Cookie(r, "missing")
+	Equal(t, err, http.ErrNoCookie)
+
+	w = httptest.NewRecorder()
+	DeleteCookie(w, NewCookie("plain", "value"))
+	deleted := w.Result().Cookies()[0]
+	Equal(t, deleted.MaxAge, -1)
+	Equal(t, deleted.Value, "")
+}
@@ -0,0 +1,254 @@
+package httpext
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// preflightVary lists the request headers a preflight response varies by.
+var preflightVary = strings.Join([]string{Origin, AccessControlRequestMethod, AccessControlRequestHeaders}, ", ")
+
+// CORS is a Cross-Origin Resource Sharing middleware configuration.
+//
+// The `CORS` is designed to be stateless and reusable,
+// configuration is copy so a base `CORS` can be used and changed per route.
+type CORS struct {
+	origins       []string
+	wildcards     [][2]string
+	allowOriginFn func(r *http.Request, origin string) bool
+	methods       []string
+	headers       []string
+	exposed       []string
+	maxAge        time.Duration
+	allowAll      bool
+	allowHeaders  bool
+	credentials   bool
+}
+
+// NewCORS returns a new `CORS` with sane default values.
+//
+// The default values are:
+//   - No origins are allowed until configured.
+//   - `AllowedMethods` are GET, HEAD and POST.
+//   - `AllowedHeaders` are Accept, Accept-Language, Content-Language and Content-Type.
+//   - `AllowCredentials` is false.
+//   - `MaxAge` is 0 which omits the `Access-Control-Max-Age` header.
+func NewCORS() CORS {
+	return CORS{
+		methods: []string{http.MethodGet, http.MethodHead, http.MethodPost},
+		headers: []string{"accept", "accept-language", "content-language", "content-type"},
+	}
+}
+
+// AllowedOrigins sets the origins which are allowed to make cross-origin requests.
+//
+// Origins can be:
+//   - An exact match eg. "https://example.com".
+//   - A wildcard subdomain eg. "https://*.example.com", which does not match "https://example.com".
+//   - "*" which allows any origin.
+func (c CORS) AllowedOrigins(origins ...string) CORS {
+	c.origins, c.wildcards, c.allowAll = nil, nil, false
+	for _, origin := range origins {
+		origin = strings.ToLower(origin)
+		if origin == Any {
+			c.allowAll = true
+		} else if i := strings.IndexByte(origin, '*'); i >= 0 {
+			c.wildcards = append(c.wildcards, [2]string{origin[:i], origin[i+1:]})
+		} else {
+			c.origins = append(c.origins, origin)
+		}
+	}
+	return c
+}
+
+// AllowOriginFn sets a predicate which is consulted for origins not matched by `AllowedOrigins`.
+func (c CORS) AllowOriginFn(fn func(r *http.Request, origin string) bool) CORS {
+	c.allowOriginFn = fn
+	return c
+}
+
+// AllowedMethods sets the methods allowed for cross-origin requests.
+func (c CORS) AllowedMethods(methods ...string) CORS {
+	c.methods = make([]string, 0, len(methods))
+	for _, method := range methods {
+		c.methods = append(c.methods, strings.ToUpper(method))
+	}
+	return c
+}
+
+// AllowedHeaders sets the request headers allowed for cross-origin requests.
+//
+// "*" allows any header requested by the client.
+func (c CORS) AllowedHeaders(headers ...string) CORS {
+	c.headers, c.allowHeaders = make([]string, 0, len(headers)), false
+	for _, header := range headers {
+		if header == Any {
+			c.allowHeaders = true
+			continue
+		}
+		c.headers = append(c.headers, strings.ToLower(header))
+	}
+	return c
+}
+
+// ExposedHeaders sets the response headers which the client is allowed to access.
+func (c CORS) ExposedHeaders(headers ...string) CORS {
+	c.exposed = headers
+	return c
+}
+
+// AllowCredentials sets if the request can include user credentials like cookies,
+// HTTP authentication or client side SSL certificates.
+//
+// NOTE: credentials are never allowed when all origins are allowed using "*",
+// as that would expose credentialed responses to any site, "*" is sent without credentials instead.
+func (c CORS) AllowCredentials(allow bool) CORS {
+	c.credentials = allow
+	return c
+}
+
+// MaxAge sets how long the results of a preflight request can be cached by the client.
+//
+// A max age of 0 omits the header and is the default.
+func (c CORS) MaxAge(maxAge time.Duration) CORS {
+	c.maxAge = maxAge
+	return c
+}
+
+// Handler returns an `http.Handler` which applies the CORS configuration before calling the next handler.
+//
+// Preflight requests are answered directly with a 204 No Content status and are not passed to the next handler.
+func (c CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions && r.Header.Get(AccessControlRequestMethod) != "" {
+			c.preflight(w, r)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		c.actual(w, r)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c CORS) preflight(w http.ResponseWriter, r *http.Request) {
+	headers := w.Header()
+	headers.Add(Vary, preflightVary)
+
+	origin := r.Header.Get(Origin)
+	if origin == "" || !c.isOriginAllowed(r, origin) {
+		return
+	}
+
+	method := strings.ToUpper(r.Header.Get(AccessControlRequestMethod))
+	if !c.isMethodAllowed(method) {
+		return
+	}
+
+	requested := parseHeaderList(r.Header.Values(AccessControlRequestHeaders))
+	if !c.areHeadersAllowed(requested) {
+		return
+	}
+
+	c.setAllowOrigin(headers, origin)
+	headers.Set(AccessControlAllowMethods, method)
+	if len(requested) > 0 {
+		headers.Set(AccessControlAllowHeaders, strings.Join(requested, ", "))
+	}
+	if c.maxAge > 0 {
+		headers.Set(AccessControlMaxAge, strconv.FormatInt(int64(c.maxAge/time.Second), 10))
+	}
+}
+
+func (c CORS) actual(w http.ResponseWriter, r *http.Request) {
+	headers := w.Header()
+	if !c.allowAll {
+		headers.Add(Vary, Origin)
+	}
+
+	origin := r.Header.Get(Origin)
+	if origin == "" || !c.isOriginAllowed(r, origin) || !c.isMethodAllowed(r.Method) {
+		return
+	}
+
+	c.setAllowOrigin(headers, origin)
+	if len(c.exposed) > 0 {
+		headers.Set(AccessControlExposeHeaders, strings.Join(c.exposed, ", "))
+	}
+}
+
+func (c CORS) setAllowOrigin(headers http.Header, origin string) {
+	if c.allowAll {
+		headers.Set(AccessControlAllowOrigin, Any)
+		return
+	}
+
+	headers.Set(AccessControlAllowOrigin, origin)
+	if c.credentials {
+		headers.Set(AccessControlAllowCredentials, "true")
+	}
+}
+
+func (c CORS) isOriginAllowed(r *http.Request, origin string) bool {
+	if c.allowAll {
+		return true
+	}
+
+	lower := strings.ToLower(origin)
+	for _, o := range c.origins {
+		if o == lower {
+			return true
+		}
+	}
+
+	for _, w := range c.wildcards {
+		if len(lower) > len(w[0])+len(w[1]) && strings.HasPrefix(lower, w[0]) && strings.HasSuffix(lower, w[1]) {
+			return true
+		}
+	}
+	return c.allowOriginFn != nil && c.allowOriginFn(r, origin)
+}
+
+func (c CORS) isMethodAllowed(method string) bool {
+	// OPTIONS is always allowed as it's used by the preflight itself
+	if method == http.MethodOptions {
+		return true
+	}
+
+	for _, m := range c.methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (c CORS) areHeadersAllowed(requested []string) bool {
+	if c.allowHeaders {
+		return true
+	}
+
+OUTER:
+	for _, header := range requested {
+		for _, h := range c.headers {
+			if h == header {
+				continue OUTER
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// parseHeaderList parses comma separated header values into a lowercase list of header names.
+func parseHeaderList(values []string) (headers []string) {
+	for _, value := range values {
+		for _, header := range strings.Split(value, ",") {
+			if header = strings.ToLower(strings.TrimSpace(header)); header != "" {
+				headers = append(headers, header)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,189 @@
+package httpext
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestCORS(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name          string
+		cors          CORS
+		method        string
+		headers       map[string]string
+		code          int
+		allowOrigin   string
+		allowMethods  string
+		allowHeaders  string
+		allowCreds    string
+		exposeHeaders string
+		maxAge        string
+		vary          string
+	}{
+		{
+			name:   "no origin",
+			cors:   NewCORS().AllowedOrigins("https://example.com"),
+			method: http.MethodGet,
+			code:   http.StatusOK,
+			vary:   Origin,
+		},
+		{
+			name:          "exact origin",
+			cors:          NewCORS().AllowedOrigins("https://example.com").ExposedHeaders("X-Request-Id"),
+			method:        http.MethodGet,
+			headers:       map[string]string{Origin: "https://Example.com"},
+			code:          http.StatusOK,
+			allowOrigin:   "https://Example.com",
+			exposeHeaders: "X-Request-Id",
+			vary:          Origin,
+		},
+		{
+			name:    "disallowed origin",
+			cors:    NewCORS().AllowedOrigins("https://example.com"),
+			method:  http.MethodGet,
+			headers: map[string]string{Origin: "https://evil.com"},
+			code:    http.StatusOK,
+			vary:    Origin,
+		},
+		{
+			name:        "wildcard subdomain",
+			cors:        NewCORS().AllowedOrigins("https://*.example.com"),
+			method:      http.MethodGet,
+			headers:     map[string]string{Origin: "https://api.example.com"},
+			code:        http.StatusOK,
+			allowOrigin: "https://api.example.com",
+			vary:        Origin,
+		},
+		{
+			name:    "wildcard subdomain does not match apex",
+			cors:    NewCORS().AllowedOrigins("https://*.example.com"),
+			method:  http.MethodGet,
+			headers: map[string]string{Origin: "https://example.com"},
+			code:    http.StatusOK,
+			vary:    Origin,
+		},
+		{
+			name: "predicate",
+			cors: NewCORS().AllowOriginFn(func(_ *http.Request, origin string) bool {
+				return strings.HasSuffix(origin, ".test")
+			}),
+			method:      http.MethodGet,
+			headers:     map[string]string{Origin: "http://app.test"},
+			code:        http.StatusOK,
+			allowOrigin: "http://app.test",
+			vary:        Origin,
+		},
+		{
+			name:        "any origin",
+			cors:        NewCORS().AllowedOrigins("*"),
+			method:      http.MethodGet,
+			headers:     map[string]string{Origin: "https://example.com"},
+			code:        http.StatusOK,
+			allowOrigin: "*",
+		},
+		{
+			name:        "any origin with credentials omits credentials",
+			cors:        NewCORS().AllowedOrigins("*").AllowCredentials(true),
+			method:      http.MethodGet,
+			headers:     map[string]string{Origin: "https://example.com"},
+			code:        http.StatusOK,
+			allowOrigin: "*",
+		},
+		{
+			name:        "origin with credentials",
+			cors:        NewCORS().AllowedOrigins("https://example.com").AllowCredentials(true),
+			method:      http.MethodGet,
+			headers:     map[string]string{Origin: "https://example.com"},
+			code:        http.StatusOK,
+			allowOrigin: "https://example.com",
+			allowCreds:  "true",
+			vary:        Origin,
+		},
+		{
+			name:   "preflight",
+			cors:   NewCORS().AllowedOrigins("https://example.com").AllowedMethods("PUT").AllowedHeaders("X-Custom").MaxAge(time.Hour),
+			method: http.MethodOptions,
+			headers: map[string]string{
+				Origin:                      "https://example.com",
+				AccessControlRequestMethod:  "PUT",
+				AccessControlRequestHeaders: "x-custom",
+			},
+			code:         http.StatusNoContent,
+			allowOrigin:  "https://example.com",
+			allowMethods: "PUT",
+			allowHeaders: "x-custom",
+			maxAge:       "3600",
+			vary:         preflightVary,
+		},
+		{
+			name:   "preflight disallowed method",
+			cors:   NewCORS().AllowedOrigins("https://example.com"),
+			method: http.MethodOptions,
+			headers: map[string]string{
+				Origin:                     "https://example.com",
+				AccessControlRequestMethod: "DELETE",
+			},
+			code: http.StatusNoContent,
+			vary: preflightVary,
+		},
+		{
+			name:   "preflight disallowed header",
+			cors:   NewCORS().AllowedOrigins("https://example.com"),
+			method: http.MethodOptions,
+			headers: map[string]string{
+				Origin:                      "https://example.com",
+				AccessControlRequestMethod:  "POST",
+				AccessControlRequestHeaders: "X-Other",
+			},
+			code: http.StatusNoContent,
+			vary: preflightVary,
+		},
+		{
+			name:   "preflight any header",
+			cors:   NewCORS().AllowedOrigins("https://example.com").AllowedHeaders("*"),
+			method: http.MethodOptions,
+			headers: map[string]string{
+				Origin:                      "https://example.com",
+				AccessControlRequestMethod:  "POST",
+				AccessControlRequestHeaders: "X-Other, X-Another",
+			},
+			code:         http.StatusNoContent,
+			allowOrigin:  "https://example.com",
+			allowMethods: "POST",
+			allowHeaders: "x-other, x-another",
+			vary:         preflightVary,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			w := httptest.NewRecorder()
+			tc.cors.Handler(ok).ServeHTTP(w, req)
+
+			Equal(t, w.Code, tc.code)
+			Equal(t, w.Header().Get(AccessControlAllowOrigin), tc.allowOrigin)
+			Equal(t, w.Header().Get(AccessControlAllowMethods), tc.allowMethods)
+			Equal(t, w.Header().Get(AccessControlAllowHeaders), tc.allowHeaders)
+			Equal(t, w.Header().Get(AccessControlAllowCredentials), tc.allowCreds)
+			Equal(t, w.Header().Get(AccessControlExposeHeaders), tc.exposeHeaders)
+			Equal(t, w.Header().Get(AccessControlMaxAge), tc.maxAge)
+			Equal(t, w.Header().Get(Vary), tc.vary)
+			Equal(t, len(w.Header().Values(Vary)) <= 1, true)
+		})
+	}
+}
@@ -2,7 +2,9 @@ package httpext
 
 import (
 	"net/url"
+	"time"
 
+	optionext "github.com/pchchv/extender/values/option"
 	"github.com/pchchv/form"
 )
 
@@ -13,6 +15,24 @@ var (
 	DefaultFormDecoder FormDecoder = form.NewDecoder()
 )
 
+func init() {
+	RegisterFormOption[string]()
+	RegisterFormOption[bool]()
+	RegisterFormOption[int]()
+	RegisterFormOption[int8]()
+	RegisterFormOption[int16]()
+	RegisterFormOption[int32]()
+	RegisterFormOption[int64]()
+	RegisterFormOption[uint]()
+	RegisterFormOption[uint8]()
+	RegisterFormOption[uint16]()
+	RegisterFormOption[uint32]()
+	RegisterFormOption[uint64]()
+	RegisterFormOption[float32]()
+	RegisterFormOption[float64]()
+	RegisterFormOption[time.Time]()
+}
+
 // FormEncoder is the type used for encoding form data.
 type FormEncoder interface {
 	Encode(interface{}) (url.Values, error)
@@ -22,3 +42,45 @@ type FormEncoder interface {
 type FormDecoder interface {
 	Decode(interface{}, url.Values) error
 }
+
+// RegisterFormOption registers `optionext.Option[T]` with the `DefaultFormEncoder` and `DefaultFormDecoder`,
+// if they support registering custom types, so that empty form values become None and
+// None values are omitted.
+//
+// Options of string, bool, all numeric types and time.Time are registered by default,
+// other types, such as custom types implementing `encoding.TextUnmarshaler`, must be registered before use.
+//
+// NOTE: This function is not thread-safe it is intended that these all be registered prior to any parsing and
+// must be called again if the `DefaultFormEncoder` or `DefaultFormDecoder` are replaced.
+func RegisterFormOption[T any]() {
+	if e, ok := DefaultFormEncoder.(interface {
+		RegisterCustomTypeFunc(form.EncodeCustomTypeFunc, ...interface{})
+	}); ok {
+		e.RegisterCustomTypeFunc(func(x interface{}) ([]string, error) {
+			o := x.(optionext.Option[T])
+			if o.IsNone() {
+				return nil, nil
+			}
+
+			text, err := o.MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			return []string{string(text)}, nil
+		}, optionext.Option[T]{})
+	}
+
+	if d, ok := DefaultFormDecoder.(interface {
+		RegisterCustomTypeFunc(form.DecodeCustomTypeFunc, ...interface{})
+	}); ok {
+		d.RegisterCustomTypeFunc(func(values []string) (interface{}, error) {
+			var o optionext.Option[T]
+			if len(values) == 0 {
+				return o, nil
+			}
+
+			err := o.UnmarshalText([]byte(values[0]))
+			return o, err
+		}, optionext.Option[T]{})
+	}
+}
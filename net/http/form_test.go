@@ -0,0 +1,54 @@
+package httpext
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+func TestFormOption(t *testing.T) {
+	type user struct {
+		Name    optionext.Option[string]    `form:"name" xml:"name"`
+		Age     optionext.Option[uint8]     `form:"age" xml:"age"`
+		Active  optionext.Option[bool]      `form:"active" xml:"active"`
+		Created optionext.Option[time.Time] `form:"created" xml:"created"`
+	}
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	values, err := DefaultFormEncoder.Encode(user{
+		Name:    optionext.Some("joeybloggs"),
+		Created: optionext.Some(created),
+	})
+	Equal(t, err, nil)
+	Equal(t, values.Get("name"), "joeybloggs")
+	Equal(t, values.Get("created"), "2024-01-02T03:04:05Z")
+	Equal(t, len(values["age"]), 0)
+	Equal(t, len(values["active"]), 0)
+	Equal(t, values.Encode(), "created=2024-01-02T03%3A04%3A05Z&name=joeybloggs")
+
+	form := url.Values{"name": {"joeybloggs"}, "age": {""}, "active": {"true"}, "created": {"2024-01-02T03:04:05Z"}}
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(form.Encode()))
+	req.Header.Set(ContentType, ApplicationForm)
+
+	var u user
+	Equal(t, DecodeForm(req, NoQueryParams, &u), nil)
+	Equal(t, u.Name, optionext.Some("joeybloggs"))
+	Equal(t, u.Age, optionext.None[uint8]())
+	Equal(t, u.Active, optionext.Some(true))
+	Equal(t, u.Created, optionext.Some(created))
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("<user><name>joeybloggs</name><age>3</age></user>"))
+	req.Header.Set(ContentType, ApplicationXML)
+
+	u = user{}
+	Equal(t, DecodeXML(req, NoQueryParams, 1024, &u), nil)
+	Equal(t, u.Name, optionext.Some("joeybloggs"))
+	Equal(t, u.Age, optionext.Some(uint8(3)))
+	Equal(t, u.Active, optionext.None[bool]())
+}
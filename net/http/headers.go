@@ -14,6 +14,7 @@ const (
 	CrossOriginResourcePolicy     string = "Cross-Origin-Resource-Policy"
 	CacheControl                  string = "Cache-Control"
 	Connection                    string = "Connection"
+	ContentDigest                 string = "Content-Digest"
 	ContentDisposition            string = "Content-Disposition"
 	ContentEncoding               string = "Content-Encoding"
 	ContentLength                 string = "Content-Length"
@@ -40,9 +41,12 @@ const (
 	ProxyAuthorization            string = "Proxy-Authorization"
 	PublicKeyPins                 string = "Public-Key-Pins"
 	RetryAfter                    string = "Retry-After"
+	Range                         string = "Range"
 	Referer                       string = "Referer"
 	Server                        string = "Server"
 	SetCookie                     string = "Set-Cookie"
+	Signature                     string = "Signature"
+	SignatureInput                string = "Signature-Input"
 	StrictTransportSecurity       string = "Strict-Transport-Security"
 	Trailer                       string = "Trailer"
 	TK                            string = "Tk"
@@ -1,6 +1,7 @@
 package httpext
 
 import (
+	"bytes"
 	"compress/gzip"
 	"encoding/json"
 	"encoding/xml"
@@ -13,11 +14,13 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	asciiext "github.com/pchchv/extender/ascii"
 	bytesext "github.com/pchchv/extender/bytes"
 	ioext "github.com/pchchv/extender/io"
+	syncext "github.com/pchchv/extender/sync"
 	. "github.com/pchchv/extender/values/option"
 )
 
@@ -268,19 +271,71 @@ func Attachment(w http.ResponseWriter, r io.Reader, filename string) (err error)
 	return
 }
 
+// encoder holds JSON and XML encoders writing to the pooled buffer currently assigned to it,
+// the encoders are pooled separately from the buffers so that they are not allocated per response.
+type encoder struct {
+	buf  *bytes.Buffer
+	json *json.Encoder
+	xml  *xml.Encoder
+}
+
+var (
+	// bufferPool holds the buffers JSON and XML responses are marshalled into before writing them.
+	bufferPool = bytesext.NewBufferPool(512, 64*bytesext.KiB)
+
+	encoderPool = syncext.NewPool(func() *encoder {
+		e := new(encoder)
+		e.json = json.NewEncoder(e)
+		e.xml = xml.NewEncoder(e)
+		return e
+	})
+
+	// jsonSizeHint and xmlSizeHint hold the size of the last response encoded,
+	// used to get a buffer of a matching size class from the bufferPool.
+	jsonSizeHint atomic.Int64
+	xmlSizeHint  atomic.Int64
+)
+
+// getEncoder returns a pooled encoder with a buffer from the bufferPool of the size class of the hint.
+func getEncoder(sizeHint *atomic.Int64) *encoder {
+	e := encoderPool.Get()
+	e.buf = bufferPool.Get(sizeHint.Load())
+	return e
+}
+
+// Write implements the `io.Writer` interface writing to the assigned buffer.
+func (e *encoder) Write(p []byte) (int, error) {
+	return e.buf.Write(p)
+}
+
+// release returns the buffer and encoder to their pools, recording the encoded size as the next size hint.
+func (e *encoder) release(sizeHint *atomic.Int64) {
+	sizeHint.Store(int64(e.buf.Len()))
+	bufferPool.Put(e.buf)
+	e.buf = nil
+	encoderPool.Put(e)
+}
+
+// discard returns the buffer to its pool after a failed encode,
+// dropping the encoder as it may have been left in an inconsistent state.
+func (e *encoder) discard() {
+	bufferPool.Put(e.buf)
+	e.buf = nil
+}
+
 // XML marshals provided interface + returns XML + status code.
 func XML(w http.ResponseWriter, status int, i interface{}) error {
-	b, err := xml.Marshal(i)
-	if err != nil {
+	e := getEncoder(&xmlSizeHint)
+	e.buf.Write(xmlHeaderBytes)
+	if err := e.xml.Encode(i); err != nil {
+		e.discard()
 		return err
 	}
+	defer e.release(&xmlSizeHint)
 
 	w.Header().Set(ContentType, ApplicationXML)
 	w.WriteHeader(status)
-	if _, err = w.Write(xmlHeaderBytes); err == nil {
-		_, err = w.Write(b)
-	}
-
+	_, err := w.Write(e.buf.Bytes())
 	return err
 }
 
@@ -298,14 +353,16 @@ func XMLBytes(w http.ResponseWriter, status int, b []byte) error {
 
 // JSON marshals provided interface + returns JSON + status code.
 func JSON(w http.ResponseWriter, status int, i interface{}) error {
-	b, err := json.Marshal(i)
-	if err != nil {
+	e := getEncoder(&jsonSizeHint)
+	if err := e.encodeJSON(i); err != nil {
+		e.discard()
 		return err
 	}
+	defer e.release(&jsonSizeHint)
 
 	w.Header().Set(ContentType, ApplicationJSON)
 	w.WriteHeader(status)
-	_, err = w.Write(b)
+	_, err := w.Write(e.buf.Bytes())
 	return err
 }
 
@@ -320,19 +377,19 @@ func JSONBytes(w http.ResponseWriter, status int, b []byte) (err error) {
 // JSONP sends a JSONP response with status code and
 // uses `callback` to construct the JSONP payload.
 func JSONP(w http.ResponseWriter, status int, i interface{}, callback string) error {
-	b, err := json.Marshal(i)
-	if err != nil {
+	e := getEncoder(&jsonSizeHint)
+	e.buf.WriteString(callback)
+	e.buf.WriteByte('(')
+	if err := e.encodeJSON(i); err != nil {
+		e.discard()
 		return err
 	}
+	defer e.release(&jsonSizeHint)
+	e.buf.WriteString(");")
 
 	w.Header().Set(ContentType, ApplicationJSON)
 	w.WriteHeader(status)
-	if _, err = w.Write([]byte(callback + "(")); err == nil {
-		if _, err = w.Write(b); err == nil {
-			_, err = w.Write([]byte(");"))
-		}
-	}
-
+	_, err := w.Write(e.buf.Bytes())
 	return err
 }
 
@@ -346,6 +403,16 @@ func JSONStream(w http.ResponseWriter, status int, i interface{}) error {
 	return json.NewEncoder(w).Encode(i)
 }
 
+// encodeJSON appends the value to the buffer producing the same output as json.Marshal.
+func (e *encoder) encodeJSON(i interface{}) error {
+	if err := e.json.Encode(i); err != nil {
+		return err
+	}
+	// remove the trailing newline added by the encoder
+	e.buf.Truncate(e.buf.Len() - 1)
+	return nil
+}
+
 func decodeQueryParams(values url.Values, v interface{}) (err error) {
 	err = DefaultFormDecoder.Decode(v, values)
 	return
@@ -487,3 +487,84 @@ func TestDecode(t *testing.T) {
 	Equal(t, test.Posted, "values")
 	Equal(t, test.MultiPartPosted, "values")
 }
+
+// discardResponseWriter is a http.ResponseWriter discarding everything written to it,
+// so only the allocations of the helpers themselves are measured.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header         { return w.header }
+func (w *discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *discardResponseWriter) WriteHeader(int)             {}
+
+type benchmarkBody struct {
+	ID    int      `json:"id" xml:"id"`
+	Name  string   `json:"name" xml:"name"`
+	Items []string `json:"items" xml:"items"`
+}
+
+func newBenchmarkBody() benchmarkBody {
+	body := benchmarkBody{ID: 1, Name: "benchmark"}
+	for i := 0; i < 200; i++ {
+		body.Items = append(body.Items, "item-"+strings.Repeat("x", 10))
+	}
+	return body
+}
+
+// jsonMarshal is the json.Marshal based implementation the JSON helper is compared against.
+func jsonMarshal(w http.ResponseWriter, status int, i interface{}) error {
+	b, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+	w.Header().Set(ContentType, ApplicationJSON)
+	w.WriteHeader(status)
+	_, err = w.Write(b)
+	return err
+}
+
+func TestJSONAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random when the race detector is enabled")
+	}
+	body := newBenchmarkBody()
+	w := &discardResponseWriter{header: make(http.Header)}
+
+	pooled := testing.AllocsPerRun(100, func() {
+		_ = JSON(w, http.StatusOK, body)
+	})
+	marshalled := testing.AllocsPerRun(100, func() {
+		_ = jsonMarshal(w, http.StatusOK, body)
+	})
+	if pooled >= marshalled {
+		t.Fatalf("JSON allocations %v not less than json.Marshal %v", pooled, marshalled)
+	}
+}
+
+func BenchmarkJSON(b *testing.B) {
+	body := newBenchmarkBody()
+	w := &discardResponseWriter{header: make(http.Header)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = JSON(w, http.StatusOK, body)
+	}
+}
+
+func BenchmarkJSONMarshal(b *testing.B) {
+	body := newBenchmarkBody()
+	w := &discardResponseWriter{header: make(http.Header)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = jsonMarshal(w, http.StatusOK, body)
+	}
+}
+
+func BenchmarkXML(b *testing.B) {
+	body := newBenchmarkBody()
+	w := &discardResponseWriter{header: make(http.Header)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = XML(w, http.StatusOK, body)
+	}
+}
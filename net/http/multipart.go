@@ -0,0 +1,98 @@
+package httpext
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	bytesext "github.com/pchchv/extender/bytes"
+	ioext "github.com/pchchv/extender/io"
+)
+
+// ErrNotMultipart is returned when the request Content-Type is not multipart/form-data or has no boundary.
+var ErrNotMultipart = errors.New("request Content-Type isn't multipart/form-data")
+
+// MultipartFileFn is called for each file part encountered while streaming a multipart form.
+//
+// The part must be read from the supplied reader, not the part itself,
+// in order for the per-part size limit to be enforced.
+// Any unread data is discarded once the function returns.
+type MultipartFileFn func(part *multipart.Part, r io.Reader) error
+
+// DecodeMultipartStream streams the requests multipart form data, without buffering files in memory or on disk,
+// decoding the non-file fields into the provided struct using the `DefaultFormDecoder` and
+// calling the provided function for each file part in the order they are received.
+//
+// Each part is limited to maxPartBytes and the entire body to maxBytes via an ioext.LimitReader,
+// exceeding either returns an error for which errors.Is(err, ioext.ErrLimitedReaderEOF) is true.
+//
+// The http method is not checked.
+//
+// A nil file function discards file parts, still counting them towards maxBytes.
+//
+// NOTE: non-file fields are only decoded into the struct after all parts have been consumed
+// and so are not yet populated when the file function is called.
+//
+// NOTE: when QueryParamsOption=QueryParams the query params will be parsed and included
+// e. g. route /user?test=true 'test' is added to parsed form fields.
+func DecodeMultipartStream(r *http.Request, qp QueryParamsOption, maxPartBytes, maxBytes bytesext.Bytes, v interface{}, fn MultipartFileFn) error {
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get(ContentType))
+	if err != nil || mediaType != MultipartForm || params["boundary"] == "" {
+		return ErrNotMultipart
+	}
+
+	values := make(url.Values)
+	body := ioext.LimitReader(r.Body, maxBytes)
+	if err = decodeMultipartParts(multipart.NewReader(body, params["boundary"]), maxPartBytes, values, fn); err != nil {
+		// the multipart reader can mask the underlying read error, so check the total limit directly
+		if body.N < 0 {
+			return ioext.ErrLimitedReaderEOF
+		}
+		return err
+	}
+
+	if qp == QueryParams {
+		for k, vs := range r.URL.Query() {
+			values[k] = append(values[k], vs...)
+		}
+	}
+	return DefaultFormDecoder.Decode(v, values)
+}
+
+func decodeMultipartParts(mr *multipart.Reader, maxPartBytes bytesext.Bytes, values url.Values, fn MultipartFileFn) error {
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		name := part.FormName()
+		if name == "" {
+			_ = part.Close()
+			continue
+		}
+
+		if part.FileName() == "" {
+			b, err := io.ReadAll(ioext.LimitReader(part, maxPartBytes))
+			_ = part.Close()
+			if err != nil {
+				return err
+			}
+			values.Add(name, string(b))
+			continue
+		}
+
+		if fn != nil {
+			err = fn(part, ioext.LimitReader(part, maxPartBytes))
+		}
+		_ = part.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
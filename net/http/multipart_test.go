@@ -0,0 +1,91 @@
+package httpext
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ioext "github.com/pchchv/extender/io"
+	. "github.com/pchchv/go-assert"
+)
+
+func TestDecodeMultipartStream(t *testing.T) {
+	type form struct {
+		ID   int    `form:"id"`
+		Name string `form:"name"`
+		Test bool   `form:"test"`
+	}
+
+	newRequest := func(t *testing.T, file string) *http.Request {
+		var buff bytes.Buffer
+		mw := multipart.NewWriter(&buff)
+		Equal(t, mw.WriteField("id", "13"), nil)
+		fw, err := mw.CreateFormFile("upload", "upload.txt")
+		Equal(t, err, nil)
+		_, err = fw.Write([]byte(file))
+		Equal(t, err, nil)
+		Equal(t, mw.WriteField("name", "joeybloggs"), nil)
+		Equal(t, mw.Close(), nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/?test=true", &buff)
+		req.Header.Set(ContentType, mw.FormDataContentType())
+		return req
+	}
+
+	var f form
+	var files []string
+	fn := func(part *multipart.Part, r io.Reader) error {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		files = append(files, part.FormName()+":"+part.FileName()+":"+string(b))
+		return nil
+	}
+
+	err := DecodeMultipartStream(newRequest(t, "file contents"), QueryParams, 1024, 10240, &f, fn)
+	Equal(t, err, nil)
+	Equal(t, f, form{ID: 13, Name: "joeybloggs", Test: true})
+	Equal(t, files, []string{"upload:upload.txt:file contents"})
+
+	f, files = form{}, nil
+	err = DecodeMultipartStream(newRequest(t, "file contents"), NoQueryParams, 1024, 10240, &f, fn)
+	Equal(t, err, nil)
+	Equal(t, f, form{ID: 13, Name: "joeybloggs"})
+
+	// unread file contents are discarded
+	err = DecodeMultipartStream(newRequest(t, "file contents"), NoQueryParams, 1024, 10240, &f, func(*multipart.Part, io.Reader) error {
+		return nil
+	})
+	Equal(t, err, nil)
+
+	// nil file function discards file parts
+	f = form{}
+	err = DecodeMultipartStream(newRequest(t, "file contents"), NoQueryParams, 1024, 10240, &f, nil)
+	Equal(t, err, nil)
+	Equal(t, f, form{ID: 13, Name: "joeybloggs"})
+
+	// per part limit
+	err = DecodeMultipartStream(newRequest(t, strings.Repeat("a", 2048)), NoQueryParams, 1024, 10240, &f, fn)
+	Equal(t, errors.Is(err, ioext.ErrLimitedReaderEOF), true)
+
+	// total limit
+	err = DecodeMultipartStream(newRequest(t, strings.Repeat("a", 512)), NoQueryParams, 1024, 256, &f, fn)
+	Equal(t, errors.Is(err, ioext.ErrLimitedReaderEOF), true)
+
+	// file callback error
+	expected := errors.New("file error")
+	err = DecodeMultipartStream(newRequest(t, "file contents"), NoQueryParams, 1024, 10240, &f, func(*multipart.Part, io.Reader) error {
+		return expected
+	})
+	Equal(t, err, expected)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("id=1"))
+	req.Header.Set(ContentType, ApplicationForm)
+	Equal(t, DecodeMultipartStream(req, NoQueryParams, 1024, 10240, &f, fn), ErrNotMultipart)
+}
@@ -0,0 +1,6 @@
+//go:build !race
+
+package httpext
+
+// raceEnabled reports whether the race detector is enabled, which makes sync.Pool drop items at random.
+const raceEnabled = false
@@ -0,0 +1,598 @@
+package httpext
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	bytesext "github.com/pchchv/extender/bytes"
+	ioext "github.com/pchchv/extender/io"
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// HTTP Message Signature algorithms, RFC 9421 Section 6.2.2.
+const (
+	SignatureAlgorithmHMACSHA256 string = "hmac-sha256"
+	SignatureAlgorithmEd25519    string = "ed25519"
+)
+
+var (
+	// ErrMissingSignature is returned when a request does not contain the expected signature headers.
+	ErrMissingSignature = errors.New("missing HTTP message signature")
+	// ErrInvalidSignature is returned when a signature or its parameters are malformed or do not verify.
+	ErrInvalidSignature = errors.New("invalid HTTP message signature")
+	// ErrSignatureExpired is returned when the signature creation time is outside the allowed clock skew
+	// or the signature has expired.
+	ErrSignatureExpired = errors.New("HTTP message signature expired")
+	// ErrUnknownSignatureKey is returned when the key used to create a signature cannot be found.
+	ErrUnknownSignatureKey = errors.New("unknown HTTP message signature key")
+	// ErrContentDigestMismatch is returned when the body does not match the Content-Digest header.
+	ErrContentDigestMismatch = errors.New("content digest mismatch")
+	// ErrInvalidSignatureKey is returned when creating a SignatureKey from a key of the wrong size.
+	ErrInvalidSignatureKey = errors.New("invalid HTTP message signature key")
+)
+
+// SignatureKey is a key used to create and/or verify HTTP Message Signatures.
+type SignatureKey struct {
+	id        string
+	algorithm string
+	sign      func(base []byte) ([]byte, error)
+	verify    func(base, sig []byte) bool
+}
+
+// NewHMACSignatureKey returns a new `SignatureKey` which signs and verifies using HMAC-SHA256.
+func NewHMACSignatureKey(id string, secret []byte) SignatureKey {
+	sign := func(base []byte) ([]byte, error) {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(base)
+		return mac.Sum(nil), nil
+	}
+	return SignatureKey{
+		id:        id,
+		algorithm: SignatureAlgorithmHMACSHA256,
+		sign:      sign,
+		verify: func(base, sig []byte) bool {
+			expected, _ := sign(base)
+			return hmac.Equal(expected, sig)
+		},
+	}
+}
+
+// NewEd25519SignatureKey returns a new `SignatureKey` which signs and verifies using the Ed25519 private key.
+//
+// `ErrInvalidSignatureKey` is returned if the key is not `ed25519.PrivateKeySize` bytes.
+func NewEd25519SignatureKey(id string, key ed25519.PrivateKey) (SignatureKey, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return SignatureKey{}, ErrInvalidSignatureKey
+	}
+
+	k, err := NewEd25519VerificationKey(id, key.Public().(ed25519.PublicKey))
+	if err != nil {
+		return SignatureKey{}, err
+	}
+	k.sign = func(base []byte) ([]byte, error) {
+		return ed25519.Sign(key, base), nil
+	}
+	return k, nil
+}
+
+// NewEd25519VerificationKey returns a new `SignatureKey` which can only verify using the Ed25519 public key.
+//
+// `ErrInvalidSignatureKey` is returned if the key is not `ed25519.PublicKeySize` bytes.
+func NewEd25519VerificationKey(id string, key ed25519.PublicKey) (SignatureKey, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return SignatureKey{}, ErrInvalidSignatureKey
+	}
+
+	return SignatureKey{
+		id:        id,
+		algorithm: SignatureAlgorithmEd25519,
+		sign: func([]byte) ([]byte, error) {
+			return nil, errors.New("ed25519 verification key cannot sign")
+		},
+		verify: func(base, sig []byte) bool {
+			return ed25519.Verify(key, base, sig)
+		},
+	}, nil
+}
+
+// ID returns the key identifier sent as the `keyid` signature parameter.
+func (k SignatureKey) ID() string {
+	return k.id
+}
+
+// Algorithm returns the signature algorithm sent as the `alg` signature parameter.
+func (k SignatureKey) Algorithm() string {
+	return k.algorithm
+}
+
+// SigningTransport is an `http.RoundTripper` which signs outgoing requests using
+// HTTP Message Signatures, RFC 9421.
+//
+// When the "content-digest" component is covered and the request has a body
+// the `Content-Digest` header, RFC 9530, is computed and added using SHA-256.
+//
+// The `SigningTransport` is designed to be used with the `Retryer` eg.
+// `NewRetryer().Client(&http.Client{Transport: NewSigningTransport(key)})`.
+type SigningTransport struct {
+	transport  http.RoundTripper
+	key        SignatureKey
+	label      string
+	components []string
+	maxBytes   bytesext.Bytes
+}
+
+// NewSigningTransport returns a new `SigningTransport` with sane default values.
+//
+// The default values are:
+//   - `Transport` is `http.DefaultTransport`.
+//   - `Label` is "sig1".
+//   - `Components` are "@method", "@authority", "@path", "@query" and "content-digest".
+//   - `MaxBytes` of the request body used to compute the content digest is 2MiB.
+func NewSigningTransport(key SignatureKey) SigningTransport {
+	return SigningTransport{
+		transport:  http.DefaultTransport,
+		key:        key,
+		label:      "sig1",
+		components: []string{"@method", "@authority", "@path", "@query", "content-digest"},
+		maxBytes:   2 * bytesext.MiB,
+	}
+}
+
+// Transport sets the underlying `http.RoundTripper` used to make requests.
+func (s SigningTransport) Transport(transport http.RoundTripper) SigningTransport {
+	s.transport = transport
+	return s
+}
+
+// Label sets the signature label used in the `Signature` and `Signature-Input` headers.
+func (s SigningTransport) Label(label string) SigningTransport {
+	s.label = label
+	return s
+}
+
+// Components sets the covered components to sign.
+//
+// Derived components are prefixed with "@" eg. "@method" while all others are treated as header field names.
+func (s SigningTransport) Components(components ...string) SigningTransport {
+	s.components = make([]string, 0, len(components))
+	for _, c := range components {
+		s.components = append(s.components, strings.ToLower(c))
+	}
+	return s
+}
+
+// MaxBytes sets the maximum request body size used when computing the content digest.
+func (s SigningTransport) MaxBytes(maxBytes bytesext.Bytes) SigningTransport {
+	s.maxBytes = maxBytes
+	return s
+}
+
+// RoundTrip implements the `http.RoundTripper` interface.
+//
+// The provided request is not modified, a clone is signed and sent.
+func (s SigningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	components := make([]string, 0, len(s.components))
+	for _, c := range s.components {
+		if c == "content-digest" {
+			if req.Body == nil || req.Body == http.NoBody {
+				continue
+			}
+
+			b, err := io.ReadAll(ioext.LimitReader(req.Body, s.maxBytes))
+			_ = req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(b))
+			req.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(b)), nil
+			}
+			req.Header.Set(ContentDigest, contentDigest(b))
+		}
+		components = append(components, c)
+	}
+
+	var params strings.Builder
+	params.WriteByte('(')
+	for i, c := range components {
+		if i > 0 {
+			params.WriteByte(' ')
+		}
+		params.WriteString(strconv.Quote(c))
+	}
+	params.WriteString(");created=")
+	params.WriteString(strconv.FormatInt(time.Now().Unix(), 10))
+	params.WriteString(";keyid=")
+	params.WriteString(strconv.Quote(s.key.id))
+	params.WriteString(";alg=")
+	params.WriteString(strconv.Quote(s.key.algorithm))
+
+	base, err := signatureBase(req, components, params.String())
+	if err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	sig, err := s.key.sign(base)
+	if err != nil {
+		closeRequestBody(req)
+		return nil, err
+	}
+
+	req.Header.Set(SignatureInput, s.label+"="+params.String())
+	req.Header.Set(Signature, s.label+"=:"+base64.StdEncoding.EncodeToString(sig)+":")
+	return s.transport.RoundTrip(req)
+}
+
+// closeRequestBody closes the request body, if any,
+// as required by the `http.RoundTripper` interface when returning an error.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// SignatureKeyFn is used to lookup the key to verify a signature using the `keyid` signature parameter.
+type SignatureKeyFn func(ctx context.Context, keyID string) optionext.Option[SignatureKey]
+
+// SignatureVerifier verifies HTTP Message Signatures, RFC 9421, on incoming requests.
+//
+// The `SignatureVerifier` is designed to be stateless and reusable,
+// configuration is copy so a base `SignatureVerifier` can be used and changed per route.
+type SignatureVerifier struct {
+	keyFn      SignatureKeyFn
+	label      string
+	components []string
+	maxSkew    time.Duration
+	maxBytes   bytesext.Bytes
+}
+
+// NewSignatureVerifier returns a new `SignatureVerifier` with sane default values.
+//
+// The default values are:
+//   - `Label` is "", which verifies the first signature present.
+//   - `RequiredComponents` are "@method", "@authority" and "@path".
+//   - `MaxSkew` is 5 minutes.
+//   - `MaxBytes` of the request body used to verify the content digest is 2MiB.
+func NewSignatureVerifier(keyFn SignatureKeyFn) SignatureVerifier {
+	return SignatureVerifier{
+		keyFn:      keyFn,
+		components: []string{"@method", "@authority", "@path"},
+		maxSkew:    5 * time.Minute,
+		maxBytes:   2 * bytesext.MiB,
+	}
+}
+
+// Label sets the signature label to verify.
+func (v SignatureVerifier) Label(label string) SignatureVerifier {
+	v.label = label
+	return v
+}
+
+// RequiredComponents sets the components which must be covered by the signature.
+//
+// NOTE: if the request has a body "content-digest" is always required.
+func (v SignatureVerifier) RequiredComponents(components ...string) SignatureVerifier {
+	v.components = make([]string, 0, len(components))
+	for _, c := range components {
+		v.components = append(v.components, strings.ToLower(c))
+	}
+	return v
+}
+
+// MaxSkew sets the maximum allowed difference between the signature creation time and now.
+func (v SignatureVerifier) MaxSkew(maxSkew time.Duration) SignatureVerifier {
+	v.maxSkew = maxSkew
+	return v
+}
+
+// MaxBytes sets the maximum request body size read when verifying the content digest.
+func (v SignatureVerifier) MaxBytes(maxBytes bytesext.Bytes) SignatureVerifier {
+	v.maxBytes = maxBytes
+	return v
+}
+
+// Verify verifies the requests signature,
+// when the content digest is covered the body is read and replaced so it can be read again.
+func (v SignatureVerifier) Verify(r *http.Request) error {
+	label, input, ok := findDictionaryMember(r.Header.Values(SignatureInput), v.label)
+	if !ok {
+		return ErrMissingSignature
+	}
+
+	_, sigValue, ok := findDictionaryMember(r.Header.Values(Signature), label)
+	if !ok || len(sigValue) < 2 || sigValue[0] != ':' || sigValue[len(sigValue)-1] != ':' {
+		return ErrMissingSignature
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(sigValue[1 : len(sigValue)-1])
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	components, params, err := parseSignatureParams(input)
+	if err != nil {
+		return err
+	}
+
+	required := v.components
+	if r.Body != nil && r.Body != http.NoBody {
+		required = append(required[:len(required):len(required)], "content-digest")
+	}
+	for _, req := range required {
+		var found bool
+		for _, c := range components {
+			if c == req {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: component %q not covered", ErrInvalidSignature, req)
+		}
+	}
+
+	now := time.Now()
+	created, err := strconv.ParseInt(params["created"], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing created parameter", ErrInvalidSignature)
+	}
+	if diff := now.Sub(time.Unix(created, 0)); diff > v.maxSkew || diff < -v.maxSkew {
+		return ErrSignatureExpired
+	}
+	if expires, ok := params["expires"]; ok {
+		exp, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: invalid expires parameter", ErrInvalidSignature)
+		}
+		if now.After(time.Unix(exp, 0)) {
+			return ErrSignatureExpired
+		}
+	}
+
+	opt := v.keyFn(r.Context(), params["keyid"])
+	if opt.IsNone() {
+		return ErrUnknownSignatureKey
+	}
+	key := opt.Unwrap()
+	if alg, ok := params["alg"]; ok && alg != key.algorithm {
+		return fmt.Errorf("%w: algorithm mismatch", ErrInvalidSignature)
+	}
+
+	base, err := signatureBase(r, components, input)
+	if err != nil {
+		return err
+	}
+	if !key.verify(base, sig) {
+		return ErrInvalidSignature
+	}
+
+	for _, c := range components {
+		if c == "content-digest" {
+			return v.verifyContentDigest(r)
+		}
+	}
+	return nil
+}
+
+// Handler returns an `http.Handler` which verifies the request signature before calling the next handler,
+// responding with 401 Unauthorized if verification fails.
+func (v SignatureVerifier) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := v.Verify(r); err != nil {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (v SignatureVerifier) verifyContentDigest(r *http.Request) error {
+	var b []byte
+	if r.Body != nil {
+		var err error
+		b, err = io.ReadAll(ioext.LimitReader(r.Body, v.maxBytes))
+		_ = r.Body.Close()
+		if err != nil {
+			return err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	expected := contentDigest(b)
+	for _, digest := range parseHeaderListRaw(r.Header.Values(ContentDigest)) {
+		if strings.HasPrefix(digest, "sha-256=") {
+			if hmac.Equal([]byte(digest), []byte(expected)) {
+				return nil
+			}
+			return ErrContentDigestMismatch
+		}
+	}
+	return ErrContentDigestMismatch
+}
+
+// signatureBase creates the signature base, RFC 9421 Section 2.5.
+func signatureBase(r *http.Request, components []string, params string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, c := range components {
+		value, err := componentValue(r, c)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(strconv.Quote(c))
+		buf.WriteString(": ")
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(`"@signature-params": `)
+	buf.WriteString(params)
+	return buf.Bytes(), nil
+}
+
+// componentValue returns the value of the component, RFC 9421 Section 2.
+func componentValue(r *http.Request, component string) (string, error) {
+	scheme := strings.ToLower(r.URL.Scheme)
+	if scheme == "" {
+		scheme = "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+	}
+
+	authority := r.Host
+	if authority == "" {
+		authority = r.URL.Host
+	}
+	authority = strings.ToLower(authority)
+	if (scheme == "http" && strings.HasSuffix(authority, ":80")) || (scheme == "https" && strings.HasSuffix(authority, ":443")) {
+		authority = authority[:strings.LastIndexByte(authority, ':')]
+	}
+
+	path := r.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	switch component {
+	case "@method":
+		return strings.ToUpper(r.Method), nil
+	case "@scheme":
+		return scheme, nil
+	case "@authority":
+		return authority, nil
+	case "@path":
+		return path, nil
+	case "@query":
+		return "?" + r.URL.RawQuery, nil
+	case "@target-uri":
+		uri := scheme + "://" + authority + path
+		if r.URL.RawQuery != "" {
+			uri += "?" + r.URL.RawQuery
+		}
+		return uri, nil
+	case "@request-target":
+		target := path
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		return target, nil
+	}
+
+	if strings.HasPrefix(component, "@") {
+		return "", fmt.Errorf("%w: unsupported component %q", ErrInvalidSignature, component)
+	}
+
+	values := r.Header.Values(component)
+	if len(values) == 0 {
+		return "", fmt.Errorf("%w: header %q not present", ErrInvalidSignature, component)
+	}
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+	return strings.Join(trimmed, ", "), nil
+}
+
+// parseSignatureParams parses the inner list of covered components and signature parameters
+// eg. ("@method" "@path");created=1618884473;keyid="test-key".
+func parseSignatureParams(input string) (components []string, params map[string]string, err error) {
+	if len(input) == 0 || input[0] != '(' {
+		return nil, nil, fmt.Errorf("%w: malformed signature input", ErrInvalidSignature)
+	}
+
+	end := strings.IndexByte(input, ')')
+	if end == -1 {
+		return nil, nil, fmt.Errorf("%w: malformed signature input", ErrInvalidSignature)
+	}
+
+	for _, c := range strings.Fields(input[1:end]) {
+		unquoted, err := strconv.Unquote(c)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: malformed component %s", ErrInvalidSignature, c)
+		}
+		components = append(components, unquoted)
+	}
+
+	params = make(map[string]string)
+	for _, param := range strings.Split(input[end+1:], ";") {
+		if param = strings.TrimSpace(param); param == "" {
+			continue
+		}
+
+		k, v, _ := strings.Cut(param, "=")
+		if strings.HasPrefix(v, `"`) {
+			if v, err = strconv.Unquote(v); err != nil {
+				return nil, nil, fmt.Errorf("%w: malformed parameter %s", ErrInvalidSignature, k)
+			}
+		}
+		params[k] = v
+	}
+	return
+}
+
+// findDictionaryMember returns the label and raw value of the dictionary member with the provided label,
+// or the first member if the label is empty.
+func findDictionaryMember(values []string, label string) (string, string, bool) {
+	for _, member := range parseHeaderListRaw(values) {
+		k, v, ok := strings.Cut(member, "=")
+		if ok && (label == "" || k == label) {
+			return k, v, true
+		}
+	}
+	return "", "", false
+}
+
+// parseHeaderListRaw splits comma separated header values, respecting parentheses and quotes, without
+// altering the case of each member.
+func parseHeaderListRaw(values []string) (members []string) {
+	for _, value := range values {
+		var depth int
+		var quoted bool
+		start := 0
+		for i := 0; i < len(value); i++ {
+			switch value[i] {
+			case '"':
+				if i == 0 || value[i-1] != '\\' {
+					quoted = !quoted
+				}
+			case '(':
+				if !quoted {
+					depth++
+				}
+			case ')':
+				if !quoted {
+					depth--
+				}
+			case ',':
+				if !quoted && depth == 0 {
+					if m := strings.TrimSpace(value[start:i]); m != "" {
+						members = append(members, m)
+					}
+					start = i + 1
+				}
+			}
+		}
+		if m := strings.TrimSpace(value[start:]); m != "" {
+			members = append(members, m)
+		}
+	}
+	return
+}
+
+// contentDigest returns the SHA-256 Content-Digest header value, RFC 9530.
+func contentDigest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+}
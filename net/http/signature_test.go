@@ -0,0 +1,211 @@
+package httpext
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+func TestHTTPMessageSignatures(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	Equal(t, err, nil)
+	edSigner, err := NewEd25519SignatureKey("ed", priv)
+	Equal(t, err, nil)
+	edVerify, err := NewEd25519VerificationKey("ed", pub)
+	Equal(t, err, nil)
+
+	tests := []struct {
+		name   string
+		signer SignatureKey
+		verify SignatureKey
+	}{
+		{
+			name:   "hmac-sha256",
+			signer: NewHMACSignatureKey("hmac", []byte("secret")),
+			verify: NewHMACSignatureKey("hmac", []byte("secret")),
+		},
+		{
+			name:   "ed25519",
+			signer: edSigner,
+			verify: edVerify,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := NewSignatureVerifier(func(_ context.Context, keyID string) optionext.Option[SignatureKey] {
+				if keyID == tc.verify.ID() {
+					return optionext.Some(tc.verify)
+				}
+				return optionext.None[SignatureKey]()
+			}).RequiredComponents("@method", "@authority", "@path", "x-custom")
+
+			server := httptest.NewServer(verifier.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				_, _ = w.Write(b)
+			})))
+			defer server.Close()
+
+			client := &http.Client{Transport: NewSigningTransport(tc.signer).
+				Components("@method", "@authority", "@path", "@query", "X-Custom", "content-digest")}
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/test?q=1", strings.NewReader("body"))
+			Equal(t, err, nil)
+			req.Header.Set("X-Custom", "value")
+			resp, err := client.Do(req)
+			Equal(t, err, nil)
+			b, _ := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			Equal(t, resp.StatusCode, http.StatusOK)
+			Equal(t, string(b), "body")
+
+			// original request must not be modified
+			Equal(t, req.Header.Get(Signature), "")
+
+			// missing covered header
+			req, _ = http.NewRequest(http.MethodGet, server.URL+"/test", nil)
+			_, err = client.Do(req)
+			Equal(t, errors.Is(err, ErrInvalidSignature), true)
+
+			// not signed
+			resp, err = http.Get(server.URL + "/test")
+			Equal(t, err, nil)
+			_ = resp.Body.Close()
+			Equal(t, resp.StatusCode, http.StatusUnauthorized)
+		})
+	}
+}
+
+type captureRoundTripper struct {
+	req *http.Request
+}
+
+func (c *captureRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.req = req
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+type closeTrackingBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeTrackingBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func TestSigningTransportClosesBodyOnError(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	Equal(t, err, nil)
+	verificationKey, err := NewEd25519VerificationKey("key", pub)
+	Equal(t, err, nil)
+
+	tests := []struct {
+		name      string
+		transport SigningTransport
+	}{
+		{
+			name:      "missing covered header",
+			transport: NewSigningTransport(NewHMACSignatureKey("key", []byte("secret"))).Components("@method", "x-missing"),
+		},
+		{
+			name:      "verification only key",
+			transport: NewSigningTransport(verificationKey).Components("@method"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			body := &closeTrackingBody{Reader: strings.NewReader("body")}
+			req, err := http.NewRequest(http.MethodPost, "https://example.com/resource", body)
+			Equal(t, err, nil)
+
+			_, err = tc.transport.Transport(new(captureRoundTripper)).RoundTrip(req)
+			NotEqual(t, err, nil)
+			Equal(t, body.closed, true)
+		})
+	}
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	key := NewHMACSignatureKey("key", []byte("secret"))
+	keyFn := func(_ context.Context, keyID string) optionext.Option[SignatureKey] {
+		if keyID == "key" {
+			return optionext.Some(key)
+		}
+		return optionext.None[SignatureKey]()
+	}
+
+	sign := func(t *testing.T, key SignatureKey, body string) *http.Request {
+		capture := new(captureRoundTripper)
+		req, err := http.NewRequest(http.MethodPut, "https://example.com:443/resource", strings.NewReader(body))
+		Equal(t, err, nil)
+		_, err = NewSigningTransport(key).Transport(capture).RoundTrip(req)
+		Equal(t, err, nil)
+
+		r := httptest.NewRequest(http.MethodPut, "https://example.com/resource", strings.NewReader(body))
+		r.Header = capture.req.Header
+		return r
+	}
+
+	verifier := NewSignatureVerifier(keyFn)
+	Equal(t, verifier.Verify(sign(t, key, "body")), nil)
+
+	r := sign(t, key, "body")
+	r.Body = io.NopCloser(strings.NewReader("tampered"))
+	Equal(t, verifier.Verify(r), ErrContentDigestMismatch)
+
+	r = sign(t, key, "body")
+	r.Method = http.MethodDelete
+	Equal(t, verifier.Verify(r), ErrInvalidSignature)
+
+	r = sign(t, NewHMACSignatureKey("key", []byte("wrong")), "body")
+	Equal(t, verifier.Verify(r), ErrInvalidSignature)
+
+	r = sign(t, NewHMACSignatureKey("unknown", []byte("secret")), "body")
+	Equal(t, verifier.Verify(r), ErrUnknownSignatureKey)
+
+	r = sign(t, key, "body")
+	r.Header.Set(SignatureInput, strings.Replace(r.Header.Get(SignatureInput), "created=", "created=1", 1))
+	Equal(t, verifier.Verify(r), ErrSignatureExpired)
+
+	r = sign(t, key, "body")
+	r.Header.Del(Signature)
+	Equal(t, verifier.Verify(r), ErrMissingSignature)
+
+	r = sign(t, key, "body")
+	err := verifier.Label("other").Verify(r)
+	Equal(t, err, ErrMissingSignature)
+
+	err = verifier.RequiredComponents("@method", "x-missing").Verify(sign(t, key, "body"))
+	Equal(t, errors.Is(err, ErrInvalidSignature), true)
+
+	Equal(t, verifier.MaxSkew(time.Hour).Verify(sign(t, key, "body")), nil)
+
+	// a body must be covered by the content digest even when the request reports no content length
+	r = sign(t, key, "")
+	r.Body = io.NopCloser(strings.NewReader("smuggled"))
+	r.ContentLength = 0
+	err = verifier.Verify(r)
+	Equal(t, errors.Is(err, ErrInvalidSignature), true)
+}
+
+func TestEd25519SignatureKeySize(t *testing.T) {
+	_, err := NewEd25519VerificationKey("key", ed25519.PublicKey("short"))
+	Equal(t, err, ErrInvalidSignatureKey)
+
+	_, err = NewEd25519SignatureKey("key", ed25519.PrivateKey("short"))
+	Equal(t, err, ErrInvalidSignatureKey)
+}
@@ -46,3 +46,31 @@ func StackLevel(skip int) (f Frame) {
 func Stack() Frame {
 	return StackLevel(1)
 }
+
+// Frames returns the full call stack skipping the number of supplied frames,
+// where a skip of 0 starts at the caller of Frames.
+func Frames(skip int) []Frame {
+	pcs := make([]uintptr, 32)
+	for {
+		n := runtime.Callers(skip+2, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, len(pcs)*2)
+	}
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(pcs)
+	results := make([]Frame, 0, len(pcs))
+	for {
+		frame, more := frames.Next()
+		results = append(results, Frame{frame})
+		if !more {
+			break
+		}
+	}
+	return results
+}
@@ -53,3 +53,22 @@ func TestStack(t *testing.T) {
 func nested(level int) Frame {
 	return StackLevel(level)
 }
+
+func TestFrames(t *testing.T) {
+	frames := nestedFrames()
+	if len(frames) < 2 {
+		t.Fatalf("TestFrames len = %d, want at least 2", len(frames))
+	}
+
+	if frames[0].Function() != "nestedFrames" {
+		t.Errorf("TestFrames Function() = %s, want nestedFrames", frames[0].Function())
+	}
+
+	if frames[1].Function() != "TestFrames" {
+		t.Errorf("TestFrames Function() = %s, want TestFrames", frames[1].Function())
+	}
+}
+
+func nestedFrames() []Frame {
+	return Frames(0)
+}
@@ -1,6 +1,8 @@
 package sliceext
 
 import (
+	"iter"
+	"slices"
 	"sort"
 
 	optionext "github.com/pchchv/extender/values/option"
@@ -89,3 +91,23 @@ func Map[T, U any](slice []T, init U, fn func(accum U, v T) U) U {
 	}
 	return accum
 }
+
+// All returns an iterator over the index-value pairs of the slice in order.
+func All[T any](slice []T) iter.Seq2[int, T] {
+	return slices.All(slice)
+}
+
+// Values returns an iterator over the slice values in order.
+func Values[T any](slice []T) iter.Seq[T] {
+	return slices.Values(slice)
+}
+
+// Backward returns an iterator over the index-value pairs of the slice in reverse order.
+func Backward[T any](slice []T) iter.Seq2[int, T] {
+	return slices.Backward(slice)
+}
+
+// Collect collects the values from the iterator into a new slice.
+func Collect[T any](seq iter.Seq[T]) []T {
+	return slices.Collect(seq)
+}
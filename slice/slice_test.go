@@ -87,6 +87,24 @@ func TestMap(t *testing.T) {
 	Equal(t, len(s2), 0)
 }
 
+func TestIterators(t *testing.T) {
+	s := []int{1, 2, 3}
+	Equal(t, Collect(Values(s)), s)
+
+	var indexes []int
+	for i, v := range All(s) {
+		Equal(t, s[i], v)
+		indexes = append(indexes, i)
+	}
+	Equal(t, indexes, []int{0, 1, 2})
+
+	indexes = indexes[:0]
+	for i := range Backward(s) {
+		indexes = append(indexes, i)
+	}
+	Equal(t, indexes, []int{2, 1, 0})
+}
+
 func BenchmarkReverse(b *testing.B) {
 	s := make([]int, 0, 1000)
 	for i := 0; i < 1000; i++ {
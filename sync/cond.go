@@ -0,0 +1,80 @@
+package syncext
+
+import (
+	"context"
+	"sync"
+
+	listext "github.com/pchchv/extender/container/list"
+)
+
+// notifyList is a context cancellable condition variable,
+// where each waiter is notified by closing its channel.
+type notifyList struct {
+	mu      sync.Mutex
+	waiters *listext.DoublyLinkedList[chan struct{}]
+}
+
+func newNotifyList() *notifyList {
+	return &notifyList{
+		waiters: listext.NewDoublyLinked[chan struct{}](),
+	}
+}
+
+// add registers a new waiter.
+//
+// It must be called while holding the lock protecting the condition,
+// to ensure a notification after the lock is released is not missed.
+func (l *notifyList) add() *listext.Node[chan struct{}] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.waiters.PushBack(make(chan struct{}))
+}
+
+// wait blocks until the waiter is notified or the context is cancelled.
+//
+// If the context is cancelled the waiter is removed, passing on any notification
+// it received concurrently so that it is not lost.
+func (l *notifyList) wait(ctx context.Context, w *listext.Node[chan struct{}]) error {
+	select {
+	case <-w.Value:
+		return nil
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	select {
+	case <-w.Value:
+		// notified after cancellation, hand the notification to the next waiter.
+		l.notifyOne()
+	default:
+		l.waiters.Remove(w)
+	}
+	return ctx.Err()
+}
+
+// notify wakes a single waiter, if any.
+func (l *notifyList) notify() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.notifyOne()
+}
+
+// broadcast wakes all waiters.
+func (l *notifyList) broadcast() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for node := l.waiters.PopFront(); node != nil; node = l.waiters.PopFront() {
+		close(node.Value)
+	}
+}
+
+func (l *notifyList) notifyOne() {
+	if node := l.waiters.PopFront(); node != nil {
+		close(node.Value)
+	}
+}
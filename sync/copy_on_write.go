@@ -0,0 +1,54 @@
+package syncext
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// CopyOnWrite holds a value that can be read concurrently without locking,
+// while writers replace the value as a whole.
+//
+// It is intended for read-heavy values, such as configuration, where the value must be treated as immutable
+// once stored and modifications are made on a copy passed to Update or Store.
+//
+// The value is shared between copies of the CopyOnWrite.
+type CopyOnWrite[T any] struct {
+	mu    *sync.Mutex
+	value *atomic.Pointer[T]
+}
+
+// NewCopyOnWrite creates a new CopyOnWrite for use.
+func NewCopyOnWrite[T any](value T) CopyOnWrite[T] {
+	c := CopyOnWrite[T]{
+		mu:    new(sync.Mutex),
+		value: new(atomic.Pointer[T]),
+	}
+	c.value.Store(&value)
+	return c
+}
+
+// Load returns the current value without locking.
+func (c CopyOnWrite[T]) Load() T {
+	return *c.value.Load()
+}
+
+// Store replaces the current value.
+func (c CopyOnWrite[T]) Store(value T) {
+	c.mu.Lock()
+	c.value.Store(&value)
+	c.mu.Unlock()
+}
+
+// Update replaces the current value with the one returned by the provided function, returning the new value.
+//
+// Writers are serialized so the function is called exactly once with the latest value,
+// it must not mutate any reference types within the value in place, but copy them instead,
+// as they may be in use by concurrent readers.
+func (c CopyOnWrite[T]) Update(fn func(T) T) T {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value := fn(*c.value.Load())
+	c.value.Store(&value)
+	return value
+}
@@ -0,0 +1,55 @@
+package syncext
+
+import (
+	"maps"
+	"sync"
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestCopyOnWrite(t *testing.T) {
+	type config struct {
+		Name    string
+		Count   int
+		Servers map[string]string
+	}
+
+	c := NewCopyOnWrite(config{Name: "a", Servers: map[string]string{}})
+	Equal(t, c.Load().Name, "a")
+
+	c.Store(config{Name: "b", Servers: map[string]string{}})
+	Equal(t, c.Load().Name, "b")
+
+	before := c.Load()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			c.Update(func(cfg config) config {
+				cfg.Count++
+				cfg.Servers = maps.Clone(cfg.Servers)
+				cfg.Servers["s"] = "server"
+				return cfg
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			_ = c.Load().Servers["s"]
+		}()
+	}
+	wg.Wait()
+
+	Equal(t, c.Load().Count, 100)
+	Equal(t, c.Load().Servers["s"], "server")
+	Equal(t, before.Count, 0)
+	Equal(t, len(before.Servers), 0)
+
+	copied := c
+	Equal(t, copied.Update(func(cfg config) config {
+		cfg.Name = "c"
+		return cfg
+	}).Name, "c")
+	Equal(t, c.Load().Name, "c")
+}
@@ -0,0 +1,292 @@
+package syncext
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	runtimeext "github.com/pchchv/extender/runtime"
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// lockDebug holds the enabled lock debugger or nil if lock debugging is disabled.
+var lockDebug atomic.Pointer[lockDebugger]
+
+// LockHold describes an exclusive lock held on a Mutex or RWMutex while lock debugging is enabled.
+type LockHold struct {
+	// Name is the name of the mutex, empty if created using NewMutex or NewRWMutex.
+	Name string
+
+	// Frame is the location the lock was acquired from.
+	Frame runtimeext.Frame
+
+	// Acquired is the time the lock was acquired.
+	Acquired time.Time
+}
+
+// LockOrderInversion describes two named mutexes being locked in opposite orders,
+// which can result in a deadlock.
+type LockOrderInversion struct {
+	// Held is the name of the mutex held while acquiring the other.
+	Held string
+
+	// Acquiring is the name of the mutex being acquired.
+	Acquiring string
+
+	// Frame is the location Acquiring is being locked from while Held is held.
+	Frame runtimeext.Frame
+
+	// PreviousFrame is the location Held was previously locked from while Acquiring was held.
+	PreviousFrame runtimeext.Frame
+}
+
+// LockDebugger is used to configure the opt-in lock debugging mode, see EnableLockDebugging.
+type LockDebugger struct {
+	holdThreshold time.Duration
+	onLongHold    func(hold LockHold, held time.Duration)
+	onInversion   func(inversion LockOrderInversion)
+}
+
+// NewLockDebugger returns a new LockDebugger with sane defaults.
+//
+// Default settings are:
+//   - HoldThreshold of 5 seconds.
+//   - No OnLongHold callback, long held locks are not reported.
+//   - No OnLockOrderInversion callback, inversions are not reported.
+func NewLockDebugger() LockDebugger {
+	return LockDebugger{
+		holdThreshold: 5 * time.Second,
+	}
+}
+
+// HoldThreshold sets the duration after which a lock still held is reported to the OnLongHold callback.
+func (d LockDebugger) HoldThreshold(threshold time.Duration) LockDebugger {
+	d.holdThreshold = threshold
+	return d
+}
+
+// OnLongHold sets the callback called, from its own goroutine, once for each lock held longer than the HoldThreshold.
+func (d LockDebugger) OnLongHold(fn func(hold LockHold, held time.Duration)) LockDebugger {
+	d.onLongHold = fn
+	return d
+}
+
+// OnLockOrderInversion sets the callback called when two named mutexes are locked in opposite orders,
+// before blocking on the lock that could deadlock.
+func (d LockDebugger) OnLockOrderInversion(fn func(inversion LockOrderInversion)) LockDebugger {
+	d.onInversion = fn
+	return d
+}
+
+// EnableLockDebugging enables the lock debugging mode for all Mutex and RWMutex exclusive locks,
+// replacing any previous configuration.
+//
+// While enabled the holder's frame and acquire time are recorded, see HeldLocks,
+// locks held longer than the threshold reported and lock-order inversions between
+// named mutexes, see NewNamedMutex, are detected.
+// Read locks of a RWMutex are not tracked.
+//
+// This adds considerable overhead to each lock and is intended for debugging and tests only.
+func EnableLockDebugging(d LockDebugger) {
+	lockDebug.Store(&lockDebugger{
+		LockDebugger: d,
+		held:         make(map[uint64][]*lockState),
+		order:        make(map[[2]string]runtimeext.Frame),
+		holders:      make(map[*lockState]struct{}),
+	})
+}
+
+// DisableLockDebugging disables the lock debugging mode.
+//
+// Locks acquired while it was enabled continue to be tracked until unlocked.
+func DisableLockDebugging() {
+	lockDebug.Store(nil)
+}
+
+// HeldLocks returns all exclusive locks currently held that were acquired while lock debugging was enabled.
+func HeldLocks() []LockHold {
+	d := lockDebug.Load()
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	states := make([]*lockState, 0, len(d.holders))
+	for s := range d.holders {
+		states = append(states, s)
+	}
+	d.mu.Unlock()
+
+	holds := make([]LockHold, 0, len(states))
+	for _, s := range states {
+		if hold := s.holder(); hold.IsSome() {
+			holds = append(holds, hold.Unwrap())
+		}
+	}
+	return holds
+}
+
+type lockDebugger struct {
+	LockDebugger
+	mu      sync.Mutex
+	held    map[uint64][]*lockState
+	order   map[[2]string]runtimeext.Frame
+	holders map[*lockState]struct{}
+}
+
+// lockAttempt is the debug information captured before acquiring a lock, its zero value means debugging is disabled.
+type lockAttempt struct {
+	dbg   *lockDebugger
+	frame runtimeext.Frame
+	goid  uint64
+}
+
+// lockState holds the debug state of a mutex and is shared between copies of the mutex.
+type lockState struct {
+	name    string
+	tracked atomic.Bool
+	mu      sync.Mutex
+	hold    optionext.Option[LockHold]
+	goid    uint64
+	timer   *time.Timer
+	dbg     *lockDebugger
+}
+
+func newLockState(name string) *lockState {
+	return &lockState{name: name}
+}
+
+// acquiring is called before acquiring the lock, skip of 0 records the caller of the function calling acquiring.
+//
+// If checkOrder is true, lock-order inversions with the named locks held by the current goroutine are reported.
+func (s *lockState) acquiring(skip int, checkOrder bool) (attempt lockAttempt) {
+	d := lockDebug.Load()
+	if d == nil {
+		return
+	}
+
+	attempt = lockAttempt{
+		dbg:   d,
+		frame: runtimeext.StackLevel(skip + 2),
+		goid:  goroutineID(),
+	}
+	if !checkOrder || s.name == "" {
+		return
+	}
+
+	var inversions []LockOrderInversion
+	d.mu.Lock()
+	for _, held := range d.held[attempt.goid] {
+		if held.name == s.name {
+			continue
+		}
+		if prev, found := d.order[[2]string{s.name, held.name}]; found {
+			inversions = append(inversions, LockOrderInversion{
+				Held:          held.name,
+				Acquiring:     s.name,
+				Frame:         attempt.frame,
+				PreviousFrame: prev,
+			})
+		}
+		if _, found := d.order[[2]string{held.name, s.name}]; !found {
+			d.order[[2]string{held.name, s.name}] = attempt.frame
+		}
+	}
+	d.mu.Unlock()
+
+	if d.onInversion != nil {
+		for _, inversion := range inversions {
+			d.onInversion(inversion)
+		}
+	}
+	return
+}
+
+// acquired is called once the lock has been acquired.
+func (s *lockState) acquired(attempt lockAttempt) {
+	d := attempt.dbg
+	if d == nil {
+		return
+	}
+
+	hold := LockHold{Name: s.name, Frame: attempt.frame, Acquired: time.Now()}
+
+	s.mu.Lock()
+	s.tracked.Store(true)
+	s.hold = optionext.Some(hold)
+	s.goid = attempt.goid
+	s.dbg = d
+	if d.onLongHold != nil {
+		s.timer = time.AfterFunc(d.holdThreshold, func() {
+			d.onLongHold(hold, time.Since(hold.Acquired))
+		})
+	}
+	s.mu.Unlock()
+
+	d.mu.Lock()
+	d.holders[s] = struct{}{}
+	if s.name != "" {
+		d.held[attempt.goid] = append(d.held[attempt.goid], s)
+	}
+	d.mu.Unlock()
+}
+
+// release is called before releasing the lock.
+func (s *lockState) release() {
+	if !s.tracked.Load() {
+		return
+	}
+
+	s.mu.Lock()
+	s.tracked.Store(false)
+	d, goid := s.dbg, s.goid
+	if d == nil {
+		s.mu.Unlock()
+		return
+	}
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	s.hold = optionext.None[LockHold]()
+	s.dbg = nil
+	s.mu.Unlock()
+
+	d.mu.Lock()
+	delete(d.holders, s)
+	held := d.held[goid]
+	for i := len(held) - 1; i >= 0; i-- {
+		if held[i] == s {
+			held = append(held[:i], held[i+1:]...)
+			break
+		}
+	}
+	if len(held) == 0 {
+		delete(d.held, goid)
+	} else {
+		d.held[goid] = held
+	}
+	d.mu.Unlock()
+}
+
+// holder returns the current holder of the lock, if acquired while lock debugging was enabled.
+func (s *lockState) holder() optionext.Option[LockHold] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hold
+}
+
+// goroutineID returns the id of the current goroutine, parsed from the stack trace header.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
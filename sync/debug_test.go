@@ -0,0 +1,113 @@
+package syncext
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestLockDebugging(t *testing.T) {
+	holds := make(chan LockHold, 1)
+	EnableLockDebugging(NewLockDebugger().
+		HoldThreshold(10 * time.Millisecond).
+		OnLongHold(func(hold LockHold, held time.Duration) {
+			holds <- hold
+		}),
+	)
+	defer DisableLockDebugging()
+
+	m := NewNamedMutex("test", 0)
+	Equal(t, m.Holder().IsNone(), true)
+
+	guard := m.Lock()
+	hold := m.Holder()
+	Equal(t, hold.IsSome(), true)
+	Equal(t, hold.Unwrap().Name, "test")
+	Equal(t, hold.Unwrap().Frame.Function(), "TestLockDebugging")
+	Equal(t, hold.Unwrap().Frame.File(), "debug_test.go")
+	Equal(t, len(HeldLocks()), 1)
+
+	select {
+	case h := <-holds:
+		Equal(t, h.Name, "test")
+	case <-time.After(time.Second):
+		t.Fatal("long hold not reported")
+	}
+	guard.Unlock()
+	Equal(t, m.Holder().IsNone(), true)
+	Equal(t, len(HeldLocks()), 0)
+
+	// released before the threshold is not reported
+	guard = m.Lock()
+	guard.Unlock()
+	time.Sleep(20 * time.Millisecond)
+	Equal(t, len(holds), 0)
+
+	// frames point to the caller for all locking methods
+	result := m.LockTimeout(time.Second)
+	Equal(t, m.Holder().Unwrap().Frame.Function(), "TestLockDebugging")
+	result.Unwrap().Unlock()
+
+	rw := NewRWMutex(0)
+	rw.PerformMut(func(v *int) {
+		Equal(t, rw.Holder().Unwrap().Frame.Function(), "TestLockDebugging")
+		Equal(t, rw.Holder().Unwrap().Name, "")
+	})
+	Equal(t, rw.Holder().IsNone(), true)
+
+	// locks acquired before disabling are still released
+	guard = m.Lock()
+	DisableLockDebugging()
+	guard.Unlock()
+	Equal(t, m.Holder().IsNone(), true)
+	Equal(t, len(HeldLocks()), 0)
+}
+
+func TestLockOrderInversion(t *testing.T) {
+	var mu sync.Mutex
+	var inversions []LockOrderInversion
+	EnableLockDebugging(NewLockDebugger().OnLockOrderInversion(func(inversion LockOrderInversion) {
+		mu.Lock()
+		inversions = append(inversions, inversion)
+		mu.Unlock()
+	}))
+	defer DisableLockDebugging()
+
+	a := NewNamedMutex("a", 0)
+	b := NewNamedRWMutex("b", 0)
+
+	ga := a.Lock()
+	gb := b.Lock()
+	gb.Unlock()
+	ga.Unlock()
+
+	// same order is fine
+	a.PerformMut(func(*int) {
+		b.PerformMut(func(*int) {})
+	})
+	Equal(t, len(inversions), 0)
+
+	// opposite order, on another goroutine, is detected before blocking
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		gb := b.Lock()
+		ga := a.TryLock()
+		Equal(t, ga.IsOk(), true)
+		ga.Unwrap().Unlock()
+		ga2 := a.Lock()
+		ga2.Unlock()
+		gb.Unlock()
+	}()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	Equal(t, len(inversions), 1)
+	Equal(t, inversions[0].Held, "b")
+	Equal(t, inversions[0].Acquiring, "a")
+	Equal(t, inversions[0].Frame.Function(), "func3")
+	Equal(t, inversions[0].PreviousFrame.Function(), "TestLockOrderInversion")
+}
@@ -0,0 +1,136 @@
+package syncext
+
+import (
+	"context"
+	"sync"
+
+	resultext "github.com/pchchv/extender/values/result"
+)
+
+// Group runs tasks concurrently, with an optional concurrency limit,
+// collecting their results in the order the tasks were added.
+//
+// A Group must not be reused after Wait is called.
+type Group[T any] struct {
+	ctx           context.Context
+	cancel        context.CancelCauseFunc
+	sem           chan struct{}
+	cancelOnError bool
+	wg            sync.WaitGroup
+	mu            sync.Mutex
+	results       []resultext.Result[T, error]
+}
+
+// NewGroup creates a new Group whose tasks receive a context derived from the provided one.
+//
+// Default settings are:
+//   - No concurrency limit.
+//   - CancelOnError enabled, the context passed to all tasks is cancelled on the first error.
+func NewGroup[T any](ctx context.Context) *Group[T] {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group[T]{
+		ctx:           ctx,
+		cancel:        cancel,
+		cancelOnError: true,
+	}
+}
+
+// Limit sets the maximum number of tasks running concurrently, a value <= 0 means no limit.
+//
+// It must be called before any tasks are added.
+func (g *Group[T]) Limit(n int) *Group[T] {
+	if n <= 0 {
+		g.sem = nil
+	} else {
+		g.sem = make(chan struct{}, n)
+	}
+	return g
+}
+
+// CancelOnError sets whether the context passed to all tasks is cancelled when any task returns an error or panics,
+// with the error as the context cause.
+//
+// It must be called before any tasks are added.
+func (g *Group[T]) CancelOnError(cancel bool) *Group[T] {
+	g.cancelOnError = cancel
+	return g
+}
+
+// Go runs the task in a new goroutine, blocking while the concurrency limit is reached.
+//
+// A panic within the task is recovered and stored as its Err result containing a *resultext.PanicError.
+// If the Group's context is cancelled before the task could start,
+// it is not run and its Err result is the context cause.
+func (g *Group[T]) Go(fn func(ctx context.Context) (T, error)) {
+	g.mu.Lock()
+	idx := len(g.results)
+	g.results = append(g.results, resultext.Result[T, error]{})
+	g.mu.Unlock()
+
+	if g.ctx.Err() != nil {
+		g.setResult(idx, resultext.Err[T](context.Cause(g.ctx)))
+		return
+	}
+
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			g.setResult(idx, resultext.Err[T](context.Cause(g.ctx)))
+			return
+		}
+		// checked again after acquiring a slot, as select picks randomly when the context is also done.
+		if g.ctx.Err() != nil {
+			<-g.sem
+			g.setResult(idx, resultext.Err[T](context.Cause(g.ctx)))
+			return
+		}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+
+		result := resultext.Try(func() (T, error) {
+			return fn(g.ctx)
+		})
+		if result.IsErr() && g.cancelOnError {
+			g.cancel(result.Err())
+		}
+		g.setResult(idx, result)
+	}()
+}
+
+// Wait blocks until all tasks have completed, returning their results in the order they were added.
+func (g *Group[T]) Wait() []resultext.Result[T, error] {
+	g.wg.Wait()
+	g.cancel(context.Canceled)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.results
+}
+
+func (g *Group[T]) setResult(idx int, result resultext.Result[T, error]) {
+	g.mu.Lock()
+	g.results[idx] = result
+	g.mu.Unlock()
+}
+
+// ParallelMap maps a slice of []T -> []Result[U, error] calling the function concurrently for each element,
+// with at most limit running at the same time, where a limit <= 0 means no limit.
+//
+// The results are in the same order as the input slice.
+// The context passed to the function is cancelled on the first error, see Group for details.
+func ParallelMap[T, U any](ctx context.Context, slice []T, limit int, fn func(ctx context.Context, v T) (U, error)) []resultext.Result[U, error] {
+	g := NewGroup[U](ctx).Limit(limit)
+	for _, v := range slice {
+		g.Go(func(ctx context.Context) (U, error) {
+			return fn(ctx, v)
+		})
+	}
+	return g.Wait()
+}
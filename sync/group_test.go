@@ -0,0 +1,144 @@
+package syncext
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	resultext "github.com/pchchv/extender/values/result"
+	. "github.com/pchchv/go-assert"
+)
+
+func TestGroup(t *testing.T) {
+	g := NewGroup[int](context.Background())
+	for i := 0; i < 5; i++ {
+		g.Go(func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration(5-i) * time.Millisecond)
+			return i, nil
+		})
+	}
+	results := g.Wait()
+	Equal(t, len(results), 5)
+	for i, r := range results {
+		Equal(t, r, resultext.Ok[int, error](i))
+	}
+}
+
+func TestGroupLimit(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	g := NewGroup[int](context.Background()).Limit(2)
+	for i := 0; i < 10; i++ {
+		g.Go(func(ctx context.Context) (int, error) {
+			n := running.Add(1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+			running.Add(-1)
+			return i, nil
+		})
+	}
+	results := g.Wait()
+	Equal(t, len(results), 10)
+	Equal(t, maxRunning.Load(), int32(2))
+}
+
+func TestGroupCancelOnError(t *testing.T) {
+	g := NewGroup[int](context.Background()).Limit(1)
+	g.Go(func(ctx context.Context) (int, error) {
+		return 0, io.EOF
+	})
+	g.Go(func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	results := g.Wait()
+	Equal(t, results[0].Err(), io.EOF)
+	// the slot is only released after the group has been cancelled, so the second task never starts
+	Equal(t, results[1].Err(), io.EOF)
+
+	g = NewGroup[int](context.Background())
+	g.Go(func(ctx context.Context) (int, error) {
+		return 0, io.EOF
+	})
+	g.Go(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, context.Cause(ctx)
+	})
+	<-g.ctx.Done()
+	var started atomic.Bool
+	g.Go(func(ctx context.Context) (int, error) {
+		started.Store(true)
+		return 2, nil
+	})
+	results = g.Wait()
+	Equal(t, results[0].Err(), io.EOF)
+	Equal(t, results[1].Err(), io.EOF)
+	Equal(t, results[2].Err(), io.EOF)
+	Equal(t, started.Load(), false)
+
+	// disabled
+	g = NewGroup[int](context.Background()).CancelOnError(false)
+	g.Go(func(ctx context.Context) (int, error) {
+		return 0, io.EOF
+	})
+	g.Go(func(ctx context.Context) (int, error) {
+		time.Sleep(5 * time.Millisecond)
+		return 1, ctx.Err()
+	})
+	results = g.Wait()
+	Equal(t, results[0].Err(), io.EOF)
+	Equal(t, results[1], resultext.Ok[int, error](1))
+}
+
+func TestGroupPanic(t *testing.T) {
+	g := NewGroup[int](context.Background())
+	g.Go(func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+	results := g.Wait()
+	var pe *resultext.PanicError
+	Equal(t, errors.As(results[0].Err(), &pe), true)
+	Equal(t, pe.Value, "boom")
+}
+
+func TestGroupParentCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := NewGroup[int](ctx).Limit(1)
+
+	block := make(chan struct{})
+	g.Go(func(ctx context.Context) (int, error) {
+		<-block
+		return 0, nil
+	})
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+	// blocks waiting for the slot until the parent context is cancelled
+	g.Go(func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	close(block)
+	results := g.Wait()
+	Equal(t, results[0], resultext.Ok[int, error](0))
+	Equal(t, results[1].Err(), context.Canceled)
+}
+
+func TestParallelMap(t *testing.T) {
+	results := ParallelMap(context.Background(), []string{"1", "2", "a"}, 2, func(ctx context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+	Equal(t, len(results), 3)
+	Equal(t, results[0], resultext.Ok[int, error](1))
+	Equal(t, results[2].IsErr(), true)
+
+	collected := resultext.Collect(results[:2])
+	Equal(t, collected, resultext.Ok[[]int, error]([]int{1, 2}))
+}
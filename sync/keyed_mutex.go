@@ -0,0 +1,118 @@
+package syncext
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	resultext "github.com/pchchv/extender/values/result"
+)
+
+// KeyedMutexGuard holds the lock of a single key of a KeyedMutex.
+type KeyedMutexGuard[K comparable] struct {
+	km    *KeyedMutex[K]
+	key   K
+	entry *keyedEntry
+}
+
+// Key returns the locked key.
+func (g KeyedMutexGuard[K]) Key() K {
+	return g.key
+}
+
+// Unlock unlocks the key.
+func (g KeyedMutexGuard[K]) Unlock() {
+	g.entry.mu.Unlock()
+	g.km.release(g.key, g.entry)
+}
+
+type keyedEntry struct {
+	mu   *ContextMutex
+	refs int
+}
+
+// KeyedMutex provides a mutual exclusion lock per key, eg. per user ID,
+// where locking one key does not block any other.
+//
+// The lock of a key is created on demand and removed once no goroutine holds or waits for it,
+// so idle keys do not use any memory.
+type KeyedMutex[K comparable] struct {
+	mu      sync.Mutex
+	entries map[K]*keyedEntry
+}
+
+// NewKeyedMutex creates a new KeyedMutex for use.
+func NewKeyedMutex[K comparable]() *KeyedMutex[K] {
+	return &KeyedMutex[K]{
+		entries: make(map[K]*keyedEntry),
+	}
+}
+
+// Lock locks the key, the calling goroutine blocks until the key is available.
+func (km *KeyedMutex[K]) Lock(key K) KeyedMutexGuard[K] {
+	entry := km.acquire(key)
+	entry.mu.Lock()
+	return KeyedMutexGuard[K]{km: km, key: key, entry: entry}
+}
+
+// LockContext locks the key, blocking until the lock is acquired or the context is cancelled,
+// returning the guard in the Ok result otherwise the context error in the Err result.
+func (km *KeyedMutex[K]) LockContext(ctx context.Context, key K) resultext.Result[KeyedMutexGuard[K], error] {
+	entry := km.acquire(key)
+	if err := entry.mu.LockContext(ctx); err != nil {
+		km.release(key, entry)
+		return resultext.Err[KeyedMutexGuard[K]](err)
+	}
+	return resultext.Ok[KeyedMutexGuard[K], error](KeyedMutexGuard[K]{km: km, key: key, entry: entry})
+}
+
+// LockTimeout locks the key, blocking until the lock is acquired or the timeout elapses,
+// returning the guard in the Ok result otherwise `context.DeadlineExceeded` in the Err result.
+func (km *KeyedMutex[K]) LockTimeout(key K, timeout time.Duration) resultext.Result[KeyedMutexGuard[K], error] {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return km.LockContext(ctx, key)
+}
+
+// TryLock tries to lock the key and reports whether it succeeded.
+// If it does the guard is returned in the Ok result otherwise Err with empty value.
+func (km *KeyedMutex[K]) TryLock(key K) resultext.Result[KeyedMutexGuard[K], struct{}] {
+	entry := km.acquire(key)
+	if !entry.mu.TryLock() {
+		km.release(key, entry)
+		return resultext.Err[KeyedMutexGuard[K]](struct{}{})
+	}
+	return resultext.Ok[KeyedMutexGuard[K], struct{}](KeyedMutexGuard[K]{km: km, key: key, entry: entry})
+}
+
+// Len returns the number of keys currently locked or waited for.
+func (km *KeyedMutex[K]) Len() int {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	return len(km.entries)
+}
+
+// acquire returns the entry for the key, creating it if needed, and registers the caller's interest in it.
+func (km *KeyedMutex[K]) acquire(key K) *keyedEntry {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	entry, found := km.entries[key]
+	if !found {
+		entry = &keyedEntry{mu: newContextMutex()}
+		km.entries[key] = entry
+	}
+	entry.refs++
+	return entry
+}
+
+// release removes the caller's interest in the entry, removing it once no longer in use.
+func (km *KeyedMutex[K]) release(key K, entry *keyedEntry) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	entry.refs--
+	if entry.refs == 0 {
+		delete(km.entries, key)
+	}
+}
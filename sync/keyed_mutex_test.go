@@ -0,0 +1,64 @@
+package syncext
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestKeyedMutex(t *testing.T) {
+	km := NewKeyedMutex[string]()
+
+	guard := km.Lock("a")
+	Equal(t, guard.Key(), "a")
+	Equal(t, km.TryLock("a").IsOk(), false)
+	Equal(t, km.LockTimeout("a", 10*time.Millisecond).Err(), context.DeadlineExceeded)
+
+	// other keys are not blocked
+	other := km.TryLock("b")
+	Equal(t, other.IsOk(), true)
+	Equal(t, km.Len(), 2)
+	other.Unwrap().Unlock()
+	Equal(t, km.Len(), 1)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		guard.Unlock()
+	}()
+	result := km.LockContext(context.Background(), "a")
+	Equal(t, result.IsOk(), true)
+	result.Unwrap().Unlock()
+
+	// idle keys are removed
+	Equal(t, km.Len(), 0)
+}
+
+func TestKeyedMutexLockContextContended(t *testing.T) {
+	km := NewKeyedMutex[string]()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for ctx.Err() == nil {
+				guard := km.Lock("a")
+				time.Sleep(100 * time.Microsecond)
+				guard.Unlock()
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		result := km.LockTimeout("a", 50*time.Millisecond)
+		Equal(t, result.IsOk(), true)
+		result.Unwrap().Unlock()
+	}
+	cancel()
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+	Equal(t, km.Len(), 0)
+}
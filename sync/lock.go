@@ -0,0 +1,201 @@
+package syncext
+
+import (
+	"context"
+	"sync"
+
+	listext "github.com/pchchv/extender/container/list"
+)
+
+// maxReaders is the weight of the write lock of a ContextRWMutex, bounding the number of concurrent readers.
+const maxReaders = 1 << 30
+
+type fifoWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// fifoLock is a weighted lock whose waiters are served in FIFO order,
+// so a large request is not starved by smaller ones, and can stop waiting when their context is cancelled.
+type fifoLock struct {
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	waiters *listext.DoublyLinkedList[fifoWaiter]
+}
+
+func newFIFOLock(size int64) *fifoLock {
+	return &fifoLock{
+		size:    size,
+		waiters: listext.NewDoublyLinked[fifoWaiter](),
+	}
+}
+
+// acquire acquires a weight of n, blocking until it is available or the context is cancelled,
+// in which case the context error is returned and the lock left unchanged.
+//
+// n must not exceed the size of the lock.
+func (l *fifoLock) acquire(ctx context.Context, n int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	if l.size-l.cur >= n && l.waiters.IsEmpty() {
+		l.cur += n
+		l.mu.Unlock()
+		return nil
+	}
+	w := l.waiters.PushBack(fifoWaiter{n: n, ready: make(chan struct{})})
+	l.mu.Unlock()
+
+	select {
+	case <-w.Value.ready:
+		return nil
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	select {
+	case <-w.Value.ready:
+		// acquired after cancellation, give the weight back.
+		l.cur -= n
+	default:
+		isFront := l.waiters.Front() == w
+		l.waiters.Remove(w)
+		if !isFront {
+			return ctx.Err()
+		}
+	}
+	// waiters behind this one may now fit.
+	l.notifyWaiters()
+	return ctx.Err()
+}
+
+// tryAcquire acquires a weight of n without blocking and reports whether it succeeded.
+func (l *fifoLock) tryAcquire(n int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.size-l.cur >= n && l.waiters.IsEmpty() {
+		l.cur += n
+		return true
+	}
+	return false
+}
+
+// release releases a weight of n, panicking with the provided message if more is released than is held.
+func (l *fifoLock) release(n int64, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cur < n {
+		panic(msg)
+	}
+	l.cur -= n
+	l.notifyWaiters()
+}
+
+func (l *fifoLock) notifyWaiters() {
+	for {
+		node := l.waiters.Front()
+		if node == nil || l.size-l.cur < node.Value.n {
+			return
+		}
+		l.cur += node.Value.n
+		l.waiters.Remove(node)
+		close(node.Value.ready)
+	}
+}
+
+// ContextMutex is the mutual exclusion lock used by Mutex,
+// which can be acquired with a context and serves its waiters in FIFO order.
+type ContextMutex struct {
+	l *fifoLock
+}
+
+func newContextMutex() *ContextMutex {
+	return &ContextMutex{l: newFIFOLock(1)}
+}
+
+// Lock locks the mutex, the calling goroutine blocks until the mutex is available.
+func (m *ContextMutex) Lock() {
+	_ = m.l.acquire(context.Background(), 1)
+}
+
+// LockContext locks the mutex, blocking until the lock is acquired or the context is cancelled,
+// in which case the context error is returned and the mutex left unchanged.
+func (m *ContextMutex) LockContext(ctx context.Context) error {
+	return m.l.acquire(ctx, 1)
+}
+
+// TryLock tries to lock the mutex without blocking and reports whether it succeeded.
+func (m *ContextMutex) TryLock() bool {
+	return m.l.tryAcquire(1)
+}
+
+// Unlock unlocks the mutex.
+//
+// It panics if the mutex is not locked.
+func (m *ContextMutex) Unlock() {
+	m.l.release(1, "syncext: unlock of unlocked mutex")
+}
+
+// ContextRWMutex is the reader/writer mutual exclusion lock used by RWMutex,
+// which can be acquired with a context and serves its waiters in FIFO order,
+// so a waiting writer excludes new readers from acquiring the lock.
+type ContextRWMutex struct {
+	l *fifoLock
+}
+
+func newContextRWMutex() *ContextRWMutex {
+	return &ContextRWMutex{l: newFIFOLock(maxReaders)}
+}
+
+// Lock locks the mutex for writing, the calling goroutine blocks until the mutex is available.
+func (m *ContextRWMutex) Lock() {
+	_ = m.l.acquire(context.Background(), maxReaders)
+}
+
+// LockContext locks the mutex for writing, blocking until the lock is acquired or the context is cancelled,
+// in which case the context error is returned and the mutex left unchanged.
+func (m *ContextRWMutex) LockContext(ctx context.Context) error {
+	return m.l.acquire(ctx, maxReaders)
+}
+
+// TryLock tries to lock the mutex for writing without blocking and reports whether it succeeded.
+func (m *ContextRWMutex) TryLock() bool {
+	return m.l.tryAcquire(maxReaders)
+}
+
+// Unlock unlocks the mutex for writing.
+//
+// It panics if the mutex is not locked for writing.
+func (m *ContextRWMutex) Unlock() {
+	m.l.release(maxReaders, "syncext: unlock of unlocked rwmutex")
+}
+
+// RLock locks the mutex for reading, the calling goroutine blocks until the mutex is available.
+func (m *ContextRWMutex) RLock() {
+	_ = m.l.acquire(context.Background(), 1)
+}
+
+// RLockContext locks the mutex for reading, blocking until the lock is acquired or the context is cancelled,
+// in which case the context error is returned and the mutex left unchanged.
+func (m *ContextRWMutex) RLockContext(ctx context.Context) error {
+	return m.l.acquire(ctx, 1)
+}
+
+// TryRLock tries to lock the mutex for reading without blocking and reports whether it succeeded.
+func (m *ContextRWMutex) TryRLock() bool {
+	return m.l.tryAcquire(1)
+}
+
+// RUnlock unlocks the mutex for reading.
+//
+// It panics if the mutex is not locked for reading.
+func (m *ContextRWMutex) RUnlock() {
+	m.l.release(1, "syncext: runlock of unlocked rwmutex")
+}
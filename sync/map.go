@@ -0,0 +1,119 @@
+package syncext
+
+import (
+	"iter"
+	"sync"
+
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// Map is a type safe wrapper around sync.Map.
+//
+// It is optimized for keys that are written once and read many times,
+// or for goroutines operating on disjoint sets of keys, see sync.Map and ShardedMap.
+//
+// The contents are shared between copies of the Map.
+type Map[K comparable, V any] struct {
+	m *sync.Map
+}
+
+// NewMap creates a new Map for use.
+func NewMap[K comparable, V any]() Map[K, V] {
+	return Map[K, V]{m: new(sync.Map)}
+}
+
+// Load returns the value stored for the key, or None if no value is present.
+func (m Map[K, V]) Load(key K) optionext.Option[V] {
+	v, ok := m.m.Load(key)
+	if !ok {
+		return optionext.None[V]()
+	}
+	value, _ := v.(V)
+	return optionext.Some(value)
+}
+
+// Store sets the value for the key.
+func (m Map[K, V]) Store(key K, value V) {
+	m.m.Store(key, value)
+}
+
+// LoadOrStore returns the existing value for the key if present, otherwise it stores and returns the provided value.
+// The loaded result is true if the value was loaded, false if stored.
+func (m Map[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	v, loaded := m.m.LoadOrStore(key, value)
+	actual, _ = v.(V)
+	return actual, loaded
+}
+
+// LoadOrCompute returns the existing value for the key if present,
+// otherwise it computes the value using the provided function, stores and returns it.
+// The loaded result is true if the value was loaded, false if computed and stored.
+//
+// The function may be called concurrently for the same key by multiple goroutines,
+// but only one of the computed values is stored and returned to all of them.
+// Use ShardedMap if the function must be called at most once per key.
+func (m Map[K, V]) LoadOrCompute(key K, fn func() V) (actual V, loaded bool) {
+	if v, ok := m.m.Load(key); ok {
+		actual, _ = v.(V)
+		return actual, true
+	}
+	return m.LoadOrStore(key, fn())
+}
+
+// LoadAndDelete deletes the value for the key, returning the previous value if any.
+func (m Map[K, V]) LoadAndDelete(key K) optionext.Option[V] {
+	v, loaded := m.m.LoadAndDelete(key)
+	if !loaded {
+		return optionext.None[V]()
+	}
+	value, _ := v.(V)
+	return optionext.Some(value)
+}
+
+// Delete deletes the value for the key.
+func (m Map[K, V]) Delete(key K) {
+	m.m.Delete(key)
+}
+
+// Swap stores the value for the key and returns the previous value if any.
+func (m Map[K, V]) Swap(key K, value V) optionext.Option[V] {
+	v, loaded := m.m.Swap(key, value)
+	if !loaded {
+		return optionext.None[V]()
+	}
+	previous, _ := v.(V)
+	return optionext.Some(previous)
+}
+
+// CompareAndSwap swaps the old and new values for the key if the value stored is equal to old.
+//
+// The value type V must be comparable at runtime otherwise it panics, see sync.Map.
+func (m Map[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	return m.m.CompareAndSwap(key, old, new)
+}
+
+// CompareAndDelete deletes the entry for the key if its value is equal to old.
+//
+// The value type V must be comparable at runtime otherwise it panics, see sync.Map.
+func (m Map[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	return m.m.CompareAndDelete(key, old)
+}
+
+// Clear deletes all the entries.
+func (m Map[K, V]) Clear() {
+	m.m.Clear()
+}
+
+// Range returns an iterator over the key-value pairs of the map.
+//
+// It has the same consistency guarantees as sync.Map.Range
+// and it is safe to modify the map while iterating.
+func (m Map[K, V]) Range() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.m.Range(func(key, value any) bool {
+			k, _ := key.(K)
+			v, _ := value.(V)
+			return yield(k, v)
+		})
+	}
+}
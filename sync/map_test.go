@@ -0,0 +1,213 @@
+package syncext
+
+import (
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	optionext "github.com/pchchv/extender/values/option"
+	. "github.com/pchchv/go-assert"
+)
+
+func TestMap(t *testing.T) {
+	m := NewMap[string, int]()
+	Equal(t, m.Load("a"), optionext.None[int]())
+
+	m.Store("a", 1)
+	Equal(t, m.Load("a"), optionext.Some(1))
+
+	v, loaded := m.LoadOrStore("a", 2)
+	Equal(t, v, 1)
+	Equal(t, loaded, true)
+
+	v, loaded = m.LoadOrStore("b", 2)
+	Equal(t, v, 2)
+	Equal(t, loaded, false)
+
+	v, loaded = m.LoadOrCompute("c", func() int { return 3 })
+	Equal(t, v, 3)
+	Equal(t, loaded, false)
+	v, loaded = m.LoadOrCompute("c", func() int { return 4 })
+	Equal(t, v, 3)
+	Equal(t, loaded, true)
+
+	Equal(t, m.Swap("c", 5), optionext.Some(3))
+	Equal(t, m.Swap("d", 6), optionext.None[int]())
+	Equal(t, m.CompareAndSwap("d", 6, 7), true)
+	Equal(t, m.CompareAndSwap("d", 6, 8), false)
+	Equal(t, m.CompareAndDelete("d", 6), false)
+	Equal(t, m.CompareAndDelete("d", 7), true)
+
+	Equal(t, m.LoadAndDelete("c"), optionext.Some(5))
+	Equal(t, m.LoadAndDelete("c"), optionext.None[int]())
+	m.Delete("b")
+
+	seen := make(map[string]int)
+	for k, v := range m.Range() {
+		seen[k] = v
+	}
+	Equal(t, seen, map[string]int{"a": 1})
+
+	m.Clear()
+	Equal(t, m.Load("a"), optionext.None[int]())
+}
+
+func TestMapNilInterface(t *testing.T) {
+	m := NewMap[any, error]()
+	m.Store("a", nil)
+	Equal(t, m.Load("a"), optionext.Some[error](nil))
+
+	v, loaded := m.LoadOrStore("a", io.EOF)
+	Equal(t, v, nil)
+	Equal(t, loaded, true)
+	v, loaded = m.LoadOrCompute("a", func() error { return io.EOF })
+	Equal(t, v, nil)
+	Equal(t, loaded, true)
+
+	Equal(t, m.Swap("a", nil), optionext.Some[error](nil))
+	m.Store(nil, nil)
+	seen := 0
+	for k, v := range m.Range() {
+		Equal(t, v, nil)
+		if k == nil {
+			seen++
+		}
+	}
+	Equal(t, seen, 1)
+	Equal(t, m.LoadAndDelete("a"), optionext.Some[error](nil))
+}
+
+func TestShardedMap(t *testing.T) {
+	m := NewShardedMap[string, int](3, nil)
+	Equal(t, len(m.shards), 4)
+	Equal(t, len(NewShardedMap[string, int](0, nil).shards), 1)
+
+	Equal(t, m.Load("a"), optionext.None[int]())
+	m.Store("a", 1)
+	Equal(t, m.Load("a"), optionext.Some(1))
+
+	v, loaded := m.LoadOrStore("a", 2)
+	Equal(t, v, 1)
+	Equal(t, loaded, true)
+
+	v, loaded = m.LoadOrStore("b", 2)
+	Equal(t, v, 2)
+	Equal(t, loaded, false)
+	Equal(t, m.Len(), 2)
+
+	Equal(t, m.LoadAndDelete("b"), optionext.Some(2))
+	Equal(t, m.LoadAndDelete("b"), optionext.None[int]())
+	m.Delete("a")
+	Equal(t, m.Len(), 0)
+
+	for i := 0; i < 100; i++ {
+		m.Store(strconv.Itoa(i), i)
+	}
+	seen := make(map[string]int)
+	for k, v := range m.Range() {
+		seen[k] = v
+		// modifying while iterating must not deadlock
+		m.Store(k, v+1)
+	}
+	Equal(t, len(seen), 100)
+	Equal(t, m.Load("1"), optionext.Some(2))
+
+	for range m.Range() {
+		break
+	}
+
+	m.Clear()
+	Equal(t, m.Len(), 0)
+
+	// custom hash
+	custom := NewShardedMap[int, int](4, func(k int) uint64 { return uint64(k) })
+	custom.Store(5, 5)
+	Equal(t, len(custom.shards[1].m), 1)
+}
+
+func TestShardedMapLoadOrCompute(t *testing.T) {
+	m := NewShardedMap[string, int](8, nil)
+
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, _ := m.LoadOrCompute("key", func() int {
+				calls.Add(1)
+				return 1
+			})
+			Equal(t, v, 1)
+		}()
+	}
+	wg.Wait()
+	Equal(t, calls.Load(), int32(1))
+}
+
+const benchmarkKeys = 1024
+
+func benchmarkKeysSlice() []string {
+	keys := make([]string, benchmarkKeys)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys
+}
+
+// benchmarkMixed runs a read heavy workload with one write for every 10 operations.
+func benchmarkMixed(b *testing.B, load func(string), store func(string, int)) {
+	keys := benchmarkKeysSlice()
+	for i, k := range keys {
+		store(k, i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var i int
+		for pb.Next() {
+			k := keys[i%benchmarkKeys]
+			if i%10 == 0 {
+				store(k, i)
+			} else {
+				load(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkRWMutexMap(b *testing.B) {
+	m := NewRWMutex(make(map[string]int))
+	benchmarkMixed(b,
+		func(k string) {
+			guard := m.RLock()
+			_ = guard.T[k]
+			guard.RUnlock()
+		},
+		func(k string, v int) {
+			guard := m.Lock()
+			(*guard.T)[k] = v
+			guard.Unlock()
+		},
+	)
+}
+
+func BenchmarkMap(b *testing.B) {
+	m := NewMap[string, int]()
+	benchmarkMixed(b,
+		func(k string) { _ = m.Load(k) },
+		func(k string, v int) { m.Store(k, v) },
+	)
+}
+
+func BenchmarkShardedMap(b *testing.B) {
+	m := NewShardedMap[string, int](32, nil)
+	benchmarkMixed(b,
+		func(k string) { _ = m.Load(k) },
+		func(k string, v int) { m.Store(k, v) },
+	)
+}
@@ -1,73 +1,180 @@
 package syncext
 
 import (
-	"sync"
+	"context"
+	"time"
 
+	optionext "github.com/pchchv/extender/values/option"
 	resultext "github.com/pchchv/extender/values/result"
 )
 
 // MutexGuard protects the inner contents of a Mutex for safety and unlocking.
 type MutexGuard[T any, M interface{ Unlock() }] struct {
-	m M
-	T T // is the inner generic type of the Mutex
+	m     M
+	state *lockState
+	// T is a pointer to the inner generic type of the Mutex,
+	// allowing in place mutation or replacement of the value while the lock is held.
+	T *T
+}
+
+// Set replaces the inner value of the Mutex.
+func (g MutexGuard[T, M]) Set(value T) {
+	*g.T = value
 }
 
 // Unlock unlocks the Mutex value.
 func (g MutexGuard[T, M]) Unlock() {
+	if g.state != nil {
+		g.state.release()
+	}
 	g.m.Unlock()
 }
 
 // Mutex creates a type safe mutex wrapper ensuring one cannot access the
 // values of a locked values without first gaining a lock.
+//
+// The value is shared between copies of the Mutex.
 type Mutex[T any] struct {
-	m     *sync.Mutex
-	value T
+	m     *ContextMutex
+	cond  *notifyList
+	state *lockState
+	value *T
 }
 
 // NewMutex creates a new Mutex for use.
 func NewMutex[T any](value T) Mutex[T] {
+	return NewNamedMutex("", value)
+}
+
+// NewNamedMutex creates a new Mutex for use with the provided name,
+// which identifies it when lock debugging is enabled and allows detecting lock-order inversions,
+// see EnableLockDebugging.
+func NewNamedMutex[T any](name string, value T) Mutex[T] {
 	return Mutex[T]{
-		m:     new(sync.Mutex),
-		value: value,
+		m:     newContextMutex(),
+		cond:  newNotifyList(),
+		state: newLockState(name),
+		value: &value,
 	}
 }
 
 // Lock locks the Mutex and returns value for use,
 // safe for mutation if the lock is already in use,
 // the calling goroutine blocks until the mutex is available.
-func (m Mutex[T]) Lock() MutexGuard[T, *sync.Mutex] {
-	m.m.Lock()
-	return MutexGuard[T, *sync.Mutex]{
-		m: m.m,
-		T: m.value,
-	}
+func (m Mutex[T]) Lock() MutexGuard[T, *ContextMutex] {
+	return m.lock(0)
 }
 
-// PerformMut safely locks and unlocks the Mutex values and performs the provided function returning its error if one
-// otherwise setting the returned value as the new mutex value.
-func (m Mutex[T]) PerformMut(f func(T)) {
-	guard := m.Lock()
+// PerformMut safely locks and unlocks the Mutex values and performs the provided function,
+// which can mutate or replace the value using the provided pointer.
+//
+// All goroutines waiting in WaitUntil are woken after the Mutex is unlocked to re-check their condition.
+func (m Mutex[T]) PerformMut(f func(*T)) {
+	guard := m.lock(0)
 	f(guard.T)
 	guard.Unlock()
+	m.Broadcast()
+}
+
+// LockContext locks the Mutex, blocking until the lock is acquired or the context is cancelled,
+// returning the value for use in the Ok result otherwise the context error in the Err result.
+func (m Mutex[T]) LockContext(ctx context.Context) resultext.Result[MutexGuard[T, *ContextMutex], error] {
+	return m.lockContext(ctx, 0)
+}
+
+// LockTimeout locks the Mutex, blocking until the lock is acquired or the timeout elapses,
+// returning the value for use in the Ok result otherwise `context.DeadlineExceeded` in the Err result.
+func (m Mutex[T]) LockTimeout(timeout time.Duration) resultext.Result[MutexGuard[T, *ContextMutex], error] {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return m.lockContext(ctx, 0)
+}
+
+// WaitUntil locks the Mutex and blocks until the provided function returns true for the value,
+// returning the locked guard in the Ok result.
+//
+// The function is called with the lock held, initially and each time the waiting goroutine is woken
+// by Notify, Broadcast or PerformMut, during which the lock is released.
+// If the context is cancelled while waiting, the lock is released and the context error returned in the Err result.
+func (m Mutex[T]) WaitUntil(ctx context.Context, fn func(T) bool) resultext.Result[MutexGuard[T, *ContextMutex], error] {
+	if err := ctx.Err(); err != nil {
+		return resultext.Err[MutexGuard[T, *ContextMutex]](err)
+	}
+
+	attempt := m.state.acquiring(0, true)
+	m.m.Lock()
+	for !fn(*m.value) {
+		w := m.cond.add()
+		m.m.Unlock()
+		if err := m.cond.wait(ctx, w); err != nil {
+			return resultext.Err[MutexGuard[T, *ContextMutex]](err)
+		}
+		m.m.Lock()
+	}
+	m.state.acquired(attempt)
+	return resultext.Ok[MutexGuard[T, *ContextMutex], error](m.guard())
+}
+
+// Notify wakes a single goroutine waiting in WaitUntil, if any, to re-check its condition.
+//
+// It should be called after changing the value using Lock, once unlocked.
+func (m Mutex[T]) Notify() {
+	m.cond.notify()
+}
+
+// Broadcast wakes all goroutines waiting in WaitUntil to re-check their condition.
+//
+// It should be called after changing the value using Lock, once unlocked.
+func (m Mutex[T]) Broadcast() {
+	m.cond.broadcast()
 }
 
 // TryLock tries to lock Mutex and reports whether it succeeded.
 // If it does the value is returned for use in the Ok result otherwise Err with empty value.
-func (m Mutex[T]) TryLock() resultext.Result[MutexGuard[T, *sync.Mutex], struct{}] {
+func (m Mutex[T]) TryLock() resultext.Result[MutexGuard[T, *ContextMutex], struct{}] {
+	attempt := m.state.acquiring(0, false)
 	if m.m.TryLock() {
-		return resultext.Ok[MutexGuard[T, *sync.Mutex], struct{}](MutexGuard[T, *sync.Mutex]{
-			m: m.m,
-			T: m.value,
-		})
+		m.state.acquired(attempt)
+		return resultext.Ok[MutexGuard[T, *ContextMutex], struct{}](m.guard())
 	} else {
-		return resultext.Err[MutexGuard[T, *sync.Mutex], struct{}](struct{}{})
+		return resultext.Err[MutexGuard[T, *ContextMutex], struct{}](struct{}{})
+	}
+}
+
+// Holder returns the current holder of the lock if it was acquired while lock debugging was enabled,
+// see EnableLockDebugging.
+func (m Mutex[T]) Holder() optionext.Option[LockHold] {
+	return m.state.holder()
+}
+
+func (m Mutex[T]) lock(skip int) MutexGuard[T, *ContextMutex] {
+	attempt := m.state.acquiring(skip+1, true)
+	m.m.Lock()
+	m.state.acquired(attempt)
+	return m.guard()
+}
+
+func (m Mutex[T]) lockContext(ctx context.Context, skip int) resultext.Result[MutexGuard[T, *ContextMutex], error] {
+	attempt := m.state.acquiring(skip+1, true)
+	if err := m.m.LockContext(ctx); err != nil {
+		return resultext.Err[MutexGuard[T, *ContextMutex]](err)
+	}
+	m.state.acquired(attempt)
+	return resultext.Ok[MutexGuard[T, *ContextMutex], error](m.guard())
+}
+
+func (m Mutex[T]) guard() MutexGuard[T, *ContextMutex] {
+	return MutexGuard[T, *ContextMutex]{
+		m:     m.m,
+		state: m.state,
+		T:     m.value,
 	}
 }
 
 // RMutexGuard protects the inner contents of a RWMutex for safety and unlocking.
 type RMutexGuard[T any] struct {
-	rw *sync.RWMutex
-	// T is the inner generic type of the Mutex
+	rw *ContextRWMutex
+	// T is a copy of the inner generic type of the Mutex for read-only use
 	T T
 }
 
@@ -78,42 +185,81 @@ func (g RMutexGuard[T]) RUnlock() {
 
 // RWMutex creates a type safe RWMutex wrapper ensuring one cannot access the
 // values of a locked values without first gaining a lock.
+//
+// The value is shared between copies of the RWMutex.
 type RWMutex[T any] struct {
-	rw    *sync.RWMutex
-	value T
+	rw    *ContextRWMutex
+	state *lockState
+	value *T
 }
 
 // NewRWMutex creates a new RWMutex for use.
 func NewRWMutex[T any](value T) RWMutex[T] {
+	return NewNamedRWMutex("", value)
+}
+
+// NewNamedRWMutex creates a new RWMutex for use with the provided name,
+// which identifies it when lock debugging is enabled and allows detecting lock-order inversions,
+// see EnableLockDebugging.
+func NewNamedRWMutex[T any](name string, value T) RWMutex[T] {
 	return RWMutex[T]{
-		rw:    new(sync.RWMutex),
-		value: value,
+		rw:    newContextRWMutex(),
+		state: newLockState(name),
+		value: &value,
 	}
 }
 
 // TryLock tries to lock RWMutex and returns the value in the Ok result if successful.
 // If it does the value is returned for use in the Ok result otherwise Err with empty value.
-func (m RWMutex[T]) TryLock() resultext.Result[MutexGuard[T, *sync.RWMutex], struct{}] {
+func (m RWMutex[T]) TryLock() resultext.Result[MutexGuard[T, *ContextRWMutex], struct{}] {
+	attempt := m.state.acquiring(0, false)
 	if m.rw.TryLock() {
-		return resultext.Ok[MutexGuard[T, *sync.RWMutex], struct{}](
-			MutexGuard[T, *sync.RWMutex]{
-				m: m.rw,
-				T: m.value,
-			})
+		m.state.acquired(attempt)
+		return resultext.Ok[MutexGuard[T, *ContextRWMutex], struct{}](m.guard())
 	} else {
-		return resultext.Err[MutexGuard[T, *sync.RWMutex]](struct{}{})
+		return resultext.Err[MutexGuard[T, *ContextRWMutex]](struct{}{})
 	}
 }
 
 // Lock locks the Mutex and returns value for use,
 // safe for mutation if the lock is already in use,
 // the calling goroutine blocks until the mutex is available.
-func (m RWMutex[T]) Lock() MutexGuard[T, *sync.RWMutex] {
-	m.rw.Lock()
-	return MutexGuard[T, *sync.RWMutex]{
-		m: m.rw,
-		T: m.value,
+func (m RWMutex[T]) Lock() MutexGuard[T, *ContextRWMutex] {
+	return m.lock(0)
+}
+
+// LockContext locks the RWMutex, blocking until the lock is acquired or the context is cancelled,
+// returning the value for use in the Ok result otherwise the context error in the Err result.
+func (m RWMutex[T]) LockContext(ctx context.Context) resultext.Result[MutexGuard[T, *ContextRWMutex], error] {
+	return m.lockContext(ctx, 0)
+}
+
+// LockTimeout locks the RWMutex, blocking until the lock is acquired or the timeout elapses,
+// returning the value for use in the Ok result otherwise `context.DeadlineExceeded` in the Err result.
+func (m RWMutex[T]) LockTimeout(timeout time.Duration) resultext.Result[MutexGuard[T, *ContextRWMutex], error] {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return m.lockContext(ctx, 0)
+}
+
+// RLockContext locks the RWMutex for reading, blocking until the lock is acquired or the context is cancelled,
+// returning the value for read-only use in the Ok result otherwise the context error in the Err result.
+func (m RWMutex[T]) RLockContext(ctx context.Context) resultext.Result[RMutexGuard[T], error] {
+	if err := m.rw.RLockContext(ctx); err != nil {
+		return resultext.Err[RMutexGuard[T]](err)
 	}
+	return resultext.Ok[RMutexGuard[T], error](RMutexGuard[T]{
+		rw: m.rw,
+		T:  *m.value,
+	})
+}
+
+// RLockTimeout locks the RWMutex for reading, blocking until the lock is acquired or the timeout elapses,
+// returning the value for read-only use in the Ok result otherwise `context.DeadlineExceeded` in the Err result.
+func (m RWMutex[T]) RLockTimeout(timeout time.Duration) resultext.Result[RMutexGuard[T], error] {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return m.RLockContext(ctx)
 }
 
 // TryRLock tries to lock RWMutex for reading and returns the value in the Ok result if successful.
@@ -123,7 +269,7 @@ func (m RWMutex[T]) TryRLock() resultext.Result[RMutexGuard[T], struct{}] {
 		return resultext.Ok[RMutexGuard[T], struct{}](
 			RMutexGuard[T]{
 				rw: m.rw,
-				T:  m.value,
+				T:  *m.value,
 			},
 		)
 	} else {
@@ -138,7 +284,7 @@ func (m RWMutex[T]) RLock() RMutexGuard[T] {
 	m.rw.RLock()
 	return RMutexGuard[T]{
 		rw: m.rw,
-		T:  m.value,
+		T:  *m.value,
 	}
 }
 
@@ -149,9 +295,40 @@ func (m RWMutex[T]) Perform(f func(T)) {
 	guard.RUnlock()
 }
 
-// PerformMut safely locks and unlocks the RWMutex mutable values and performs the provided function.
-func (m RWMutex[T]) PerformMut(f func(T)) {
-	guard := m.Lock()
+// PerformMut safely locks and unlocks the RWMutex mutable values and performs the provided function,
+// which can mutate or replace the value using the provided pointer.
+func (m RWMutex[T]) PerformMut(f func(*T)) {
+	guard := m.lock(0)
 	f(guard.T)
 	guard.Unlock()
 }
+
+// Holder returns the current holder of the exclusive lock if it was acquired while lock debugging was enabled,
+// see EnableLockDebugging.
+func (m RWMutex[T]) Holder() optionext.Option[LockHold] {
+	return m.state.holder()
+}
+
+func (m RWMutex[T]) lock(skip int) MutexGuard[T, *ContextRWMutex] {
+	attempt := m.state.acquiring(skip+1, true)
+	m.rw.Lock()
+	m.state.acquired(attempt)
+	return m.guard()
+}
+
+func (m RWMutex[T]) lockContext(ctx context.Context, skip int) resultext.Result[MutexGuard[T, *ContextRWMutex], error] {
+	attempt := m.state.acquiring(skip+1, true)
+	if err := m.rw.LockContext(ctx); err != nil {
+		return resultext.Err[MutexGuard[T, *ContextRWMutex]](err)
+	}
+	m.state.acquired(attempt)
+	return resultext.Ok[MutexGuard[T, *ContextRWMutex], error](m.guard())
+}
+
+func (m RWMutex[T]) guard() MutexGuard[T, *ContextRWMutex] {
+	return MutexGuard[T, *ContextRWMutex]{
+		m:     m.rw,
+		state: m.state,
+		T:     m.value,
+	}
+}
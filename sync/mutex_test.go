@@ -1,8 +1,10 @@
 package syncext
 
 import (
+	"context"
 	"sync"
 	"testing"
+	"time"
 
 	resultext "github.com/pchchv/extender/values/result"
 	. "github.com/pchchv/go-assert"
@@ -11,17 +13,17 @@ import (
 func TestMutex(t *testing.T) {
 	m := NewMutex(make(map[string]int))
 	guard := m.Lock()
-	guard.T["foo"] = 1
+	(*guard.T)["foo"] = 1
 	guard.Unlock()
-	m.PerformMut(func(m map[string]int) {
-		m["boo"] = 1
+	m.PerformMut(func(m *map[string]int) {
+		(*m)["boo"] = 1
 	})
 	guard = m.Lock()
-	myMap := guard.T
+	myMap := *guard.T
 	Equal(t, 2, len(myMap))
 	Equal(t, myMap["foo"], 1)
 	Equal(t, myMap["boo"], 1)
-	Equal(t, m.TryLock(), resultext.Err[MutexGuard[map[string]int, *sync.Mutex]](struct{}{}))
+	Equal(t, m.TryLock(), resultext.Err[MutexGuard[map[string]int, *ContextMutex]](struct{}{}))
 	guard.Unlock()
 
 	result := m.TryLock()
@@ -29,19 +31,148 @@ func TestMutex(t *testing.T) {
 	result.Unwrap().Unlock()
 }
 
+func TestMutexValueReplacement(t *testing.T) {
+	type counter struct {
+		Count int
+	}
+
+	m := NewMutex(0)
+	m.PerformMut(func(v *int) {
+		*v++
+	})
+	guard := m.Lock()
+	Equal(t, *guard.T, 1)
+	guard.Set(5)
+	guard.Unlock()
+
+	// copies share the value
+	copied := m
+	guard = copied.Lock()
+	Equal(t, *guard.T, 5)
+	guard.Unlock()
+
+	ms := NewMutex(counter{})
+	ms.PerformMut(func(c *counter) {
+		c.Count = 2
+	})
+	guard2 := ms.Lock()
+	Equal(t, guard2.T.Count, 2)
+	guard2.Unlock()
+
+	rw := NewRWMutex(counter{})
+	rw.PerformMut(func(c *counter) {
+		*c = counter{Count: 3}
+	})
+	rw.Perform(func(c counter) {
+		Equal(t, c.Count, 3)
+	})
+	wguard := rw.Lock()
+	wguard.Set(counter{Count: 4})
+	wguard.Unlock()
+	rguard := rw.RLock()
+	Equal(t, rguard.T.Count, 4)
+	rguard.RUnlock()
+}
+
+func TestMutexWaitUntil(t *testing.T) {
+	m := NewMutex(make(map[string]int))
+
+	var wg sync.WaitGroup
+	results := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := m.WaitUntil(context.Background(), func(m map[string]int) bool {
+				return m["ready"] == 1
+			})
+			Equal(t, result.IsOk(), true)
+			guard := result.Unwrap()
+			results <- (*guard.T)["ready"]
+			guard.Unlock()
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	m.PerformMut(func(m *map[string]int) {
+		(*m)["other"] = 1
+	})
+	time.Sleep(10 * time.Millisecond)
+	Equal(t, len(results), 0)
+
+	m.PerformMut(func(m *map[string]int) {
+		(*m)["ready"] = 1
+	})
+	wg.Wait()
+	close(results)
+	for v := range results {
+		Equal(t, v, 1)
+	}
+
+	// already satisfied
+	result := m.WaitUntil(context.Background(), func(m map[string]int) bool { return true })
+	Equal(t, result.IsOk(), true)
+	result.Unwrap().Unlock()
+}
+
+func TestMutexWaitUntilNotify(t *testing.T) {
+	m := NewMutex(make(map[string]int))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		result := m.WaitUntil(context.Background(), func(m map[string]int) bool {
+			return m["count"] == 2
+		})
+		Equal(t, result.IsOk(), true)
+		result.Unwrap().Unlock()
+	}()
+
+	for i := 0; i < 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+		guard := m.Lock()
+		(*guard.T)["count"]++
+		guard.Unlock()
+		m.Notify()
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("waiter not notified")
+	}
+}
+
+func TestMutexWaitUntilCancel(t *testing.T) {
+	m := NewMutex(make(map[string]int))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	result := m.WaitUntil(ctx, func(m map[string]int) bool { return false })
+	Equal(t, result.Err(), context.DeadlineExceeded)
+
+	// lock must have been released and waiter removed
+	guard := m.TryLock()
+	Equal(t, guard.IsOk(), true)
+	guard.Unwrap().Unlock()
+	Equal(t, m.cond.waiters.Len(), 0)
+
+	result = m.WaitUntil(ctx, func(m map[string]int) bool { return true })
+	Equal(t, result.Err(), context.DeadlineExceeded)
+}
+
 func TestRWMutex(t *testing.T) {
 	m := NewRWMutex(make(map[string]int))
 	guard := m.Lock()
-	guard.T["foo"] = 1
+	(*guard.T)["foo"] = 1
 	Equal(t, m.TryLock().IsOk(), false)
 	Equal(t, m.TryRLock().IsOk(), false)
 	guard.Unlock()
 
-	m.PerformMut(func(m map[string]int) {
-		m["boo"] = 2
+	m.PerformMut(func(m *map[string]int) {
+		(*m)["boo"] = 2
 	})
 	guard = m.Lock()
-	mp := guard.T
+	mp := *guard.T
 	Equal(t, mp["foo"], 1)
 	Equal(t, mp["boo"], 2)
 	guard.Unlock()
@@ -63,3 +194,104 @@ func TestRWMutex(t *testing.T) {
 	Equal(t, myMap["boo"], 2)
 	rguard.RUnlock()
 }
+
+func TestMutexLockContext(t *testing.T) {
+	m := NewMutex(make(map[string]int))
+	result := m.LockContext(context.Background())
+	Equal(t, result.IsOk(), true)
+	guard := result.Unwrap()
+
+	Equal(t, m.LockTimeout(10*time.Millisecond).Err(), context.DeadlineExceeded)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	Equal(t, m.LockContext(ctx).Err(), context.Canceled)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		guard.Unlock()
+	}()
+	result = m.LockTimeout(time.Second)
+	Equal(t, result.IsOk(), true)
+	result.Unwrap().Unlock()
+
+	// a cancelled waiter must not acquire the lock later on
+	Equal(t, m.TryLock().IsOk(), true)
+}
+
+func TestRWMutexLockContext(t *testing.T) {
+	m := NewRWMutex(make(map[string]int))
+	rresult := m.RLockContext(context.Background())
+	Equal(t, rresult.IsOk(), true)
+	rguard := rresult.Unwrap()
+
+	// multiple readers allowed, writers blocked
+	rresult = m.RLockTimeout(10 * time.Millisecond)
+	Equal(t, rresult.IsOk(), true)
+	rresult.Unwrap().RUnlock()
+	Equal(t, m.LockTimeout(10*time.Millisecond).Err(), context.DeadlineExceeded)
+	rguard.RUnlock()
+
+	result := m.LockContext(context.Background())
+	Equal(t, result.IsOk(), true)
+	guard := result.Unwrap()
+	Equal(t, m.RLockTimeout(10*time.Millisecond).Err(), context.DeadlineExceeded)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	Equal(t, m.RLockContext(ctx).Err(), context.Canceled)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		guard.Unlock()
+	}()
+	rresult = m.RLockTimeout(time.Second)
+	Equal(t, rresult.IsOk(), true)
+	rresult.Unwrap().RUnlock()
+}
+
+func TestLockContextContended(t *testing.T) {
+	m := NewMutex(0)
+	rw := NewRWMutex(0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				guard := m.Lock()
+				time.Sleep(100 * time.Microsecond)
+				guard.Unlock()
+
+				wguard := rw.Lock()
+				time.Sleep(100 * time.Microsecond)
+				wguard.Unlock()
+			}
+		}()
+	}
+
+	// waiters are queued, so a timed out lock never starves behind the contending goroutines
+	for i := 0; i < 20; i++ {
+		result := m.LockTimeout(50 * time.Millisecond)
+		Equal(t, result.IsOk(), true)
+		result.Unwrap().Unlock()
+
+		wresult := rw.LockTimeout(50 * time.Millisecond)
+		Equal(t, wresult.IsOk(), true)
+		wresult.Unwrap().Unlock()
+
+		rresult := rw.RLockTimeout(50 * time.Millisecond)
+		Equal(t, rresult.IsOk(), true)
+		rresult.Unwrap().RUnlock()
+	}
+	cancel()
+	wg.Wait()
+}
+
+func TestContextMutexUnlockPanics(t *testing.T) {
+	PanicMatches(t, func() { newContextMutex().Unlock() }, "syncext: unlock of unlocked mutex")
+	PanicMatches(t, func() { newContextRWMutex().Unlock() }, "syncext: unlock of unlocked rwmutex")
+	PanicMatches(t, func() { newContextRWMutex().RUnlock() }, "syncext: runlock of unlocked rwmutex")
+}
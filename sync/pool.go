@@ -0,0 +1,42 @@
+package syncext
+
+import "sync"
+
+// Pool is a type safe wrapper around sync.Pool with an optional reset hook.
+type Pool[T any] struct {
+	pool  sync.Pool
+	reset func(T) bool
+}
+
+// NewPool creates a new Pool using the provided function to create new values when the pool is empty.
+func NewPool[T any](newFn func() T) *Pool[T] {
+	return &Pool[T]{
+		pool: sync.Pool{
+			New: func() any {
+				return newFn()
+			},
+		},
+	}
+}
+
+// Reset sets the hook called with each value passed to Put, to reset its state before it is reused,
+// returning false discards the value instead of retaining it in the pool, eg. when it grew too large.
+//
+// It must be called before the Pool is used.
+func (p *Pool[T]) Reset(fn func(v T) (retain bool)) *Pool[T] {
+	p.reset = fn
+	return p
+}
+
+// Get returns a value from the pool, creating a new one if the pool is empty.
+func (p *Pool[T]) Get() T {
+	return p.pool.Get().(T)
+}
+
+// Put returns the value to the pool, after calling the reset hook if set.
+func (p *Pool[T]) Put(v T) {
+	if p.reset != nil && !p.reset(v) {
+		return
+	}
+	p.pool.Put(v)
+}
@@ -0,0 +1,36 @@
+package syncext
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestPool(t *testing.T) {
+	var created int
+	p := NewPool(func() *bytes.Buffer {
+		created++
+		return new(bytes.Buffer)
+	}).Reset(func(buf *bytes.Buffer) bool {
+		buf.Reset()
+		return buf.Cap() <= 1024
+	})
+
+	buf := p.Get()
+	Equal(t, created, 1)
+	buf.WriteString("test")
+	p.Put(buf)
+
+	buf = p.Get()
+	Equal(t, buf.Len(), 0)
+
+	// discarded by the reset hook
+	large := p.Get()
+	large.Grow(4096)
+	p.Put(large)
+
+	p2 := NewPool(func() int { return 1 })
+	Equal(t, p2.Get(), 1)
+	p2.Put(2)
+}
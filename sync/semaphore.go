@@ -0,0 +1,45 @@
+package syncext
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSemaphoreWeight is returned when acquiring a weight larger than the size of the Semaphore,
+// which could never succeed.
+var ErrSemaphoreWeight = errors.New("syncext: acquired weight exceeds semaphore size")
+
+// Semaphore is a weighted semaphore limiting access to a resource of a fixed size.
+//
+// Waiters are served in FIFO order, so a large request is not starved by smaller ones.
+type Semaphore struct {
+	l *fifoLock
+}
+
+// NewSemaphore creates a new Semaphore with the provided total size for use.
+func NewSemaphore(size int64) *Semaphore {
+	return &Semaphore{l: newFIFOLock(size)}
+}
+
+// Acquire acquires the semaphore with a weight of n, blocking until it is available or the context is cancelled.
+//
+// On failure the context error, or ErrSemaphoreWeight if n exceeds the size of the Semaphore,
+// is returned and the semaphore left unchanged.
+func (s *Semaphore) Acquire(ctx context.Context, n int64) error {
+	if n > s.l.size {
+		return ErrSemaphoreWeight
+	}
+	return s.l.acquire(ctx, n)
+}
+
+// TryAcquire acquires the semaphore with a weight of n without blocking and reports whether it succeeded.
+func (s *Semaphore) TryAcquire(n int64) bool {
+	return s.l.tryAcquire(n)
+}
+
+// Release releases the semaphore with a weight of n.
+//
+// It panics if more is released than is currently held.
+func (s *Semaphore) Release(n int64) {
+	s.l.release(n, "syncext: semaphore released more than held")
+}
@@ -0,0 +1,74 @@
+package syncext
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestSemaphore(t *testing.T) {
+	s := NewSemaphore(3)
+	ctx := context.Background()
+
+	Equal(t, s.Acquire(ctx, 2), nil)
+	Equal(t, s.TryAcquire(2), false)
+	Equal(t, s.TryAcquire(1), true)
+	Equal(t, s.Acquire(ctx, 4), ErrSemaphoreWeight)
+
+	acquired := make(chan struct{})
+	go func() {
+		Equal(t, s.Acquire(ctx, 2), nil)
+		close(acquired)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	s.Release(1)
+	select {
+	case <-acquired:
+		t.Fatal("acquired before enough weight was released")
+	case <-time.After(10 * time.Millisecond):
+	}
+	// waiters are served in order, so smaller requests do not jump the queue
+	Equal(t, s.TryAcquire(1), false)
+
+	s.Release(2)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("waiter not woken")
+	}
+	s.Release(2)
+
+	PanicMatches(t, func() { s.Release(1) }, "syncext: semaphore released more than held")
+}
+
+func TestSemaphoreCancel(t *testing.T) {
+	s := NewSemaphore(2)
+	Equal(t, s.TryAcquire(2), true)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	Equal(t, s.Acquire(ctx, 2), context.DeadlineExceeded)
+	Equal(t, s.l.waiters.Len(), 0)
+
+	// a cancelled large waiter at the front must not block smaller ones behind it
+	ctx, cancel = context.WithCancel(context.Background())
+	large := make(chan error)
+	go func() {
+		large <- s.Acquire(ctx, 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	small := make(chan error)
+	go func() {
+		small <- s.Acquire(context.Background(), 1)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	s.Release(1)
+	cancel()
+	Equal(t, <-large, context.Canceled)
+	Equal(t, <-small, nil)
+	s.Release(2)
+	Equal(t, s.TryAcquire(2), true)
+}
@@ -0,0 +1,184 @@
+package syncext
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"sync"
+	"unsafe"
+
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// ShardedMap is a concurrent map split into shards, each protected by its own RWMutex,
+// reducing lock contention compared to a single RWMutex protecting a map.
+//
+// The contents are shared between copies of the ShardedMap.
+type ShardedMap[K comparable, V any] struct {
+	shards []mapShard[K, V]
+	mask   uint64
+	hash   func(K) uint64
+}
+
+// cacheLineSize is the size of a CPU cache line on common architectures.
+const cacheLineSize = 64
+
+// mapShard is padded to a full cache line, the shards are stored contiguously
+// so that locking one shard does not contend with its neighbours through false sharing.
+type mapShard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+	_  [cacheLineSize - unsafe.Sizeof(sync.RWMutex{}) - unsafe.Sizeof(map[int]int(nil))]byte
+}
+
+// NewShardedMap creates a new ShardedMap for use.
+//
+// The number of shards is rounded up to the next power of two, with a minimum of one,
+// and the hash function is used to select a shard for a key.
+// If the hash function is nil, a randomly seeded `maphash.Comparable` is used.
+func NewShardedMap[K comparable, V any](shards int, hash func(K) uint64) ShardedMap[K, V] {
+	if shards < 1 {
+		shards = 1
+	}
+	shards = 1 << bits.Len(uint(shards-1))
+
+	if hash == nil {
+		seed := maphash.MakeSeed()
+		hash = func(key K) uint64 {
+			return maphash.Comparable(seed, key)
+		}
+	}
+
+	m := ShardedMap[K, V]{
+		shards: make([]mapShard[K, V], shards),
+		mask:   uint64(shards - 1),
+		hash:   hash,
+	}
+	for i := range m.shards {
+		m.shards[i].m = make(map[K]V)
+	}
+	return m
+}
+
+func (m ShardedMap[K, V]) shard(key K) *mapShard[K, V] {
+	return &m.shards[m.hash(key)&m.mask]
+}
+
+// Load returns the value stored for the key, or None if no value is present.
+func (m ShardedMap[K, V]) Load(key K) optionext.Option[V] {
+	s := m.shard(key)
+	s.mu.RLock()
+	v, ok := s.m[key]
+	s.mu.RUnlock()
+	return optionext.FromComma(v, ok)
+}
+
+// Store sets the value for the key.
+func (m ShardedMap[K, V]) Store(key K, value V) {
+	s := m.shard(key)
+	s.mu.Lock()
+	s.m[key] = value
+	s.mu.Unlock()
+}
+
+// LoadOrStore returns the existing value for the key if present, otherwise it stores and returns the provided value.
+// The loaded result is true if the value was loaded, false if stored.
+func (m ShardedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	return m.LoadOrCompute(key, func() V {
+		return value
+	})
+}
+
+// LoadOrCompute returns the existing value for the key if present,
+// otherwise it computes the value using the provided function, stores and returns it.
+// The loaded result is true if the value was loaded, false if computed and stored.
+//
+// The function is called at most once per key, while holding the lock of the key's shard,
+// and so must not access the ShardedMap.
+func (m ShardedMap[K, V]) LoadOrCompute(key K, fn func() V) (actual V, loaded bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	v, ok := s.m[key]
+	s.mu.RUnlock()
+	if ok {
+		return v, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok = s.m[key]; ok {
+		return v, true
+	}
+	v = fn()
+	s.m[key] = v
+	return v, false
+}
+
+// LoadAndDelete deletes the value for the key, returning the previous value if any.
+func (m ShardedMap[K, V]) LoadAndDelete(key K) optionext.Option[V] {
+	s := m.shard(key)
+	s.mu.Lock()
+	v, ok := s.m[key]
+	delete(s.m, key)
+	s.mu.Unlock()
+	return optionext.FromComma(v, ok)
+}
+
+// Delete deletes the value for the key.
+func (m ShardedMap[K, V]) Delete(key K) {
+	s := m.shard(key)
+	s.mu.Lock()
+	delete(s.m, key)
+	s.mu.Unlock()
+}
+
+// Len returns the number of entries across all shards.
+//
+// The shards are not locked at the same time so concurrent modifications may not be reflected.
+func (m ShardedMap[K, V]) Len() (n int) {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return
+}
+
+// Clear deletes all the entries.
+func (m ShardedMap[K, V]) Clear() {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.Lock()
+		clear(s.m)
+		s.mu.Unlock()
+	}
+}
+
+// Range returns an iterator over the key-value pairs of the map.
+//
+// Each shard is copied under its read lock before its entries are yielded,
+// so it is safe to modify the map while iterating,
+// however modifications may or may not be reflected in the remaining shards.
+func (m ShardedMap[K, V]) Range() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var keys []K
+		var values []V
+		for i := range m.shards {
+			s := &m.shards[i]
+			keys, values = keys[:0], values[:0]
+			s.mu.RLock()
+			for k, v := range s.m {
+				keys = append(keys, k)
+				values = append(values, v)
+			}
+			s.mu.RUnlock()
+
+			for j := range keys {
+				if !yield(keys[j], values[j]) {
+					return
+				}
+			}
+		}
+	}
+}
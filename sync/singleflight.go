@@ -0,0 +1,163 @@
+package syncext
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	resultext "github.com/pchchv/extender/values/result"
+)
+
+// SingleFlight deduplicates concurrent calls for the same key,
+// so that only one call is in-flight at a time and its result is shared with all callers,
+// optionally memoizing successful results for a TTL.
+type SingleFlight[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*flightCall[V]
+	ttl   time.Duration
+}
+
+type flightCall[V any] struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	result  resultext.Result[V, error]
+	waiters int
+	expires time.Time
+	timer   *time.Timer
+}
+
+// NewSingleFlight creates a new SingleFlight for use.
+//
+// Default settings are:
+//   - TTL of 0, results are not memoized and only shared with concurrent callers.
+func NewSingleFlight[K comparable, V any]() *SingleFlight[K, V] {
+	return &SingleFlight[K, V]{
+		calls: make(map[K]*flightCall[V]),
+	}
+}
+
+// TTL sets the duration successful results are memoized for and returned to subsequent callers without calling
+// the function again, a value <= 0 disables memoization.
+//
+// It must be called before Do is called.
+func (s *SingleFlight[K, V]) TTL(ttl time.Duration) *SingleFlight[K, V] {
+	s.ttl = ttl
+	return s
+}
+
+// Do calls the function for the key, if there is no call already in-flight or memoized result,
+// and returns its result.
+//
+// The function is called with a context that retains the values of the first caller's context,
+// but is only cancelled once all callers waiting for the result have had their context cancelled,
+// in which case the context error of each caller is returned in its Err result.
+//
+// A panic within the function is recovered and returned as an Err containing a *resultext.PanicError.
+func (s *SingleFlight[K, V]) Do(ctx context.Context, key K, fn func(ctx context.Context) (V, error)) resultext.Result[V, error] {
+	if err := ctx.Err(); err != nil {
+		return resultext.Err[V](err)
+	}
+
+	s.mu.Lock()
+	c, found := s.calls[key]
+	if found && !c.expires.IsZero() {
+		if time.Now().Before(c.expires) {
+			s.mu.Unlock()
+			return c.result
+		}
+		s.deleteLocked(key, c)
+		found = false
+	}
+	if !found {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &flightCall[V]{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+		s.calls[key] = c
+		go s.call(callCtx, key, c, fn)
+	}
+	c.waiters++
+	s.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.result
+	case <-ctx.Done():
+		s.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			select {
+			case <-c.done:
+			default:
+				// no callers remain, cancel the shared call and ensure new callers start a new one
+				c.cancel()
+				if s.calls[key] == c {
+					delete(s.calls, key)
+				}
+			}
+		}
+		s.mu.Unlock()
+		return resultext.Err[V](ctx.Err())
+	}
+}
+
+func (s *SingleFlight[K, V]) call(ctx context.Context, key K, c *flightCall[V], fn func(ctx context.Context) (V, error)) {
+	result := resultext.Try(func() (V, error) {
+		return fn(ctx)
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c.result = result
+	close(c.done)
+	c.cancel()
+
+	if s.calls[key] != c {
+		return
+	}
+	if s.ttl <= 0 || result.IsErr() {
+		delete(s.calls, key)
+		return
+	}
+
+	c.expires = time.Now().Add(s.ttl)
+	c.timer = time.AfterFunc(s.ttl, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.calls[key] == c {
+			delete(s.calls, key)
+		}
+	})
+}
+
+// Forget forgets the key, discarding any memoized result,
+// so that the next call to Do calls the function again even if a call is in-flight.
+//
+// Callers already waiting on an in-flight call still receive its result.
+func (s *SingleFlight[K, V]) Forget(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, found := s.calls[key]; found {
+		s.deleteLocked(key, c)
+	}
+}
+
+// Clear forgets all keys, see Forget.
+func (s *SingleFlight[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, c := range s.calls {
+		s.deleteLocked(key, c)
+	}
+}
+
+func (s *SingleFlight[K, V]) deleteLocked(key K, c *flightCall[V]) {
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	delete(s.calls, key)
+}
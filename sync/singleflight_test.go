@@ -0,0 +1,140 @@
+package syncext
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	resultext "github.com/pchchv/extender/values/result"
+	. "github.com/pchchv/go-assert"
+)
+
+func TestSingleFlight(t *testing.T) {
+	s := NewSingleFlight[string, int]()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 1, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Equal(t, s.Do(context.Background(), "key", fn), resultext.Ok[int, error](1))
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	Equal(t, calls.Load(), int32(1))
+
+	// not memoized without a TTL
+	Equal(t, s.Do(context.Background(), "key", fn), resultext.Ok[int, error](1))
+	Equal(t, calls.Load(), int32(2))
+
+	// errors and panics
+	Equal(t, s.Do(context.Background(), "err", func(ctx context.Context) (int, error) {
+		return 0, io.EOF
+	}).Err(), io.EOF)
+
+	var pe *resultext.PanicError
+	Equal(t, errors.As(s.Do(context.Background(), "panic", func(ctx context.Context) (int, error) {
+		panic("boom")
+	}).Err(), &pe), true)
+}
+
+func TestSingleFlightTTL(t *testing.T) {
+	s := NewSingleFlight[string, int]().TTL(20 * time.Millisecond)
+
+	var calls atomic.Int32
+	fn := func(ctx context.Context) (int, error) {
+		return int(calls.Add(1)), nil
+	}
+
+	Equal(t, s.Do(context.Background(), "key", fn).Unwrap(), 1)
+	Equal(t, s.Do(context.Background(), "key", fn).Unwrap(), 1)
+
+	time.Sleep(30 * time.Millisecond)
+	Equal(t, s.Do(context.Background(), "key", fn).Unwrap(), 2)
+
+	s.Forget("key")
+	Equal(t, s.Do(context.Background(), "key", fn).Unwrap(), 3)
+
+	s.Clear()
+	Equal(t, s.Do(context.Background(), "key", fn).Unwrap(), 4)
+
+	// errors are not memoized
+	var errCalls int
+	errFn := func(ctx context.Context) (int, error) {
+		errCalls++
+		return 0, io.EOF
+	}
+	s.Do(context.Background(), "err", errFn)
+	s.Do(context.Background(), "err", errFn)
+	Equal(t, errCalls, 2)
+
+	// expired entries are removed
+	time.Sleep(30 * time.Millisecond)
+	s.mu.Lock()
+	Equal(t, len(s.calls), 0)
+	s.mu.Unlock()
+}
+
+func TestSingleFlightCancel(t *testing.T) {
+	s := NewSingleFlight[string, int]()
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			close(cancelled)
+			return 0, ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+			return 1, nil
+		}
+	}
+
+	// one caller cancelling does not cancel the shared call while another waits
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	done := make(chan resultext.Result[int, error])
+	go func() { done <- s.Do(ctx1, "key", fn) }()
+	<-started
+	go func() { done <- s.Do(context.Background(), "key", fn) }()
+	time.Sleep(5 * time.Millisecond)
+	cancel1()
+
+	r1, r2 := <-done, <-done
+	if r1.IsErr() {
+		r1, r2 = r2, r1
+	}
+	Equal(t, r1, resultext.Ok[int, error](1))
+	Equal(t, r2.Err(), context.Canceled)
+
+	// all callers cancelling cancels the shared call
+	started = make(chan struct{})
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel2()
+	}()
+	Equal(t, s.Do(ctx2, "key2", fn).Err(), context.Canceled)
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("shared call not cancelled")
+	}
+
+	// already cancelled
+	Equal(t, s.Do(ctx2, "key3", fn).Err(), context.Canceled)
+}
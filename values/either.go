@@ -0,0 +1,166 @@
+package valuesext
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// ErrInvalidEitherJSON is returned when unmarshalling JSON that does not contain exactly one of `left` or `right`.
+var ErrInvalidEitherJSON = errors.New("valuesext: invalid Either JSON, expected exactly one of 'left' or 'right'")
+
+// Either represents a value that is one of two possible types, Left or Right.
+//
+// Unlike Result neither side implies failure.
+//
+// This implements the `json.Marshaler` and `json.Unmarshaler` interfaces,
+// encoded as `{"left":<value>}` or `{"right":<value>}`,
+// and the sql.Scanner and driver.Valuer interfaces storing the JSON encoding.
+type Either[L, R any] struct {
+	left    L
+	right   R
+	isRight bool
+}
+
+// IsLeft returns true if the Either contains a Left value.
+func (e Either[L, R]) IsLeft() bool {
+	return !e.isRight
+}
+
+// IsRight returns true if the Either contains a Right value.
+func (e Either[L, R]) IsRight() bool {
+	return e.isRight
+}
+
+// Left returns the Left value as Some, returns None otherwise.
+func (e Either[L, R]) Left() optionext.Option[L] {
+	if e.isRight {
+		return optionext.None[L]()
+	}
+
+	return optionext.Some(e.left)
+}
+
+// Right returns the Right value as Some, returns None otherwise.
+func (e Either[L, R]) Right() optionext.Option[R] {
+	if e.isRight {
+		return optionext.Some(e.right)
+	}
+
+	return optionext.None[R]()
+}
+
+// UnwrapLeft returns the Left value or panics.
+func (e Either[L, R]) UnwrapLeft() L {
+	if e.isRight {
+		panic("Either.UnwrapLeft: either is Right")
+	}
+
+	return e.left
+}
+
+// UnwrapRight returns the Right value or panics.
+func (e Either[L, R]) UnwrapRight() R {
+	if !e.isRight {
+		panic("Either.UnwrapRight: either is Left")
+	}
+
+	return e.right
+}
+
+// Swap returns a new Either with the Left and Right values swapped.
+func (e Either[L, R]) Swap() Either[R, L] {
+	return Either[R, L]{left: e.right, right: e.left, isRight: !e.isRight}
+}
+
+// MarshalJSON implements the `json.Marshaler` interface.
+func (e Either[L, R]) MarshalJSON() ([]byte, error) {
+	if e.isRight {
+		return json.Marshal(struct {
+			Right R `json:"right"`
+		}{e.right})
+	}
+
+	return json.Marshal(struct {
+		Left L `json:"left"`
+	}{e.left})
+}
+
+// UnmarshalJSON implements the `json.Unmarshaler` interface.
+func (e *Either[L, R]) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	leftData, isLeft := m["left"]
+	rightData, isRight := m["right"]
+	if len(m) != 1 || isLeft == isRight {
+		return ErrInvalidEitherJSON
+	}
+
+	if isRight {
+		var v R
+		if err := json.Unmarshal(rightData, &v); err != nil {
+			return err
+		}
+		*e = Right[L](v)
+		return nil
+	}
+
+	var v L
+	if err := json.Unmarshal(leftData, &v); err != nil {
+		return err
+	}
+	*e = Left[L, R](v)
+	return nil
+}
+
+// Value implements the driver.Valuer interface storing the Either as its JSON encoding.
+func (e Either[L, R]) Value() (driver.Value, error) {
+	return e.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface reading the Either from its JSON encoding.
+func (e *Either[L, R]) Scan(value any) error {
+	return scanJSON(value, e)
+}
+
+// Left creates an Either containing the Left value.
+func Left[L, R any](value L) Either[L, R] {
+	return Either[L, R]{left: value}
+}
+
+// Right creates an Either containing the Right value.
+func Right[L, R any](value R) Either[L, R] {
+	return Either[L, R]{right: value, isRight: true}
+}
+
+// MapLeft maps the Left value using the provided function, leaving a Right value untouched.
+func MapLeft[L, R, U any](e Either[L, R], fn func(L) U) Either[U, R] {
+	if e.isRight {
+		return Right[U](e.right)
+	}
+
+	return Left[U, R](fn(e.left))
+}
+
+// MapRight maps the Right value using the provided function, leaving a Left value untouched.
+func MapRight[L, R, U any](e Either[L, R], fn func(R) U) Either[L, U] {
+	if e.isRight {
+		return Right[L](fn(e.right))
+	}
+
+	return Left[L, U](e.left)
+}
+
+// Fold returns the result of calling leftFn with the Left value or rightFn with the Right value.
+func Fold[L, R, U any](e Either[L, R], leftFn func(L) U, rightFn func(R) U) U {
+	if e.isRight {
+		return rightFn(e.right)
+	}
+
+	return leftFn(e.left)
+}
@@ -0,0 +1,32 @@
+package valuesext
+
+import (
+	"sync"
+
+	resultext "github.com/pchchv/extender/values/result"
+)
+
+// Lazy is a value that is computed once, on first access, and safe for concurrent use.
+//
+// The result of the computation, including any error, is cached and returned on all subsequent calls.
+type Lazy[T any] struct {
+	once   sync.Once
+	fn     func() (T, error)
+	result resultext.Result[T, error]
+}
+
+// NewLazy creates a new Lazy that computes its value using the provided function.
+func NewLazy[T any](fn func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{fn: fn}
+}
+
+// Get computes the value on the first call and returns the cached Result on all subsequent calls.
+//
+// A panic within the function is recovered and returned as an Err, see resultext.Try.
+func (l *Lazy[T]) Get() resultext.Result[T, error] {
+	l.once.Do(func() {
+		l.result = resultext.Try(l.fn)
+		l.fn = nil
+	})
+	return l.result
+}
@@ -0,0 +1,104 @@
+package valuesext
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"iter"
+	"slices"
+
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// ErrEmptySlice is returned when unmarshalling an empty JSON array, or null, into a NonEmptySlice.
+var ErrEmptySlice = errors.New("valuesext: slice must not be empty")
+
+// NonEmptySlice is a slice that is guaranteed to contain at least one element.
+//
+// The zero value is not valid and must be created using NewNonEmptySlice, NonEmptySliceFrom or by unmarshalling.
+//
+// This implements the `json.Marshaler` and `json.Unmarshaler` interfaces encoded as a JSON array,
+// rejecting empty arrays and null with ErrEmptySlice,
+// and the sql.Scanner and driver.Valuer interfaces storing the JSON encoding.
+type NonEmptySlice[T any] struct {
+	s []T
+}
+
+// NewNonEmptySlice creates a NonEmptySlice from the first and remaining elements.
+func NewNonEmptySlice[T any](first T, rest ...T) NonEmptySlice[T] {
+	s := make([]T, 0, len(rest)+1)
+	s = append(s, first)
+	return NonEmptySlice[T]{s: append(s, rest...)}
+}
+
+// NonEmptySliceFrom creates a NonEmptySlice from a copy of the slice, returns None if the slice is empty.
+func NonEmptySliceFrom[T any](s []T) optionext.Option[NonEmptySlice[T]] {
+	if len(s) == 0 {
+		return optionext.None[NonEmptySlice[T]]()
+	}
+
+	return optionext.Some(NonEmptySlice[T]{s: slices.Clone(s)})
+}
+
+// First returns the first element.
+func (n NonEmptySlice[T]) First() T {
+	return n.s[0]
+}
+
+// Last returns the last element.
+func (n NonEmptySlice[T]) Last() T {
+	return n.s[len(n.s)-1]
+}
+
+// Len returns the number of elements, which is always at least one.
+func (n NonEmptySlice[T]) Len() int {
+	return len(n.s)
+}
+
+// Slice returns the underlying slice, which must not be modified to be empty.
+func (n NonEmptySlice[T]) Slice() []T {
+	return n.s
+}
+
+// Append returns a new NonEmptySlice with the values appended.
+func (n NonEmptySlice[T]) Append(values ...T) NonEmptySlice[T] {
+	return NonEmptySlice[T]{s: append(slices.Clip(n.s), values...)}
+}
+
+// All returns an iterator over the slice values in order.
+func (n NonEmptySlice[T]) All() iter.Seq[T] {
+	return slices.Values(n.s)
+}
+
+// MarshalJSON implements the `json.Marshaler` interface.
+func (n NonEmptySlice[T]) MarshalJSON() ([]byte, error) {
+	if len(n.s) == 0 {
+		return nil, ErrEmptySlice
+	}
+
+	return json.Marshal(n.s)
+}
+
+// UnmarshalJSON implements the `json.Unmarshaler` interface.
+func (n *NonEmptySlice[T]) UnmarshalJSON(data []byte) error {
+	var s []T
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if len(s) == 0 {
+		return ErrEmptySlice
+	}
+
+	n.s = s
+	return nil
+}
+
+// Value implements the driver.Valuer interface storing the NonEmptySlice as its JSON encoding.
+func (n NonEmptySlice[T]) Value() (driver.Value, error) {
+	return n.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface reading the NonEmptySlice from its JSON encoding.
+func (n *NonEmptySlice[T]) Scan(value any) error {
+	return scanJSON(value, n)
+}
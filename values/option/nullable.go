@@ -0,0 +1,194 @@
+package optionext
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+const (
+	undefinedState nullableState = iota
+	nullState
+	valueState
+)
+
+type nullableState uint8
+
+// Nullable represents a tri-state value that is either Undefined, Null or contains a Value.
+//
+// This is primarily used for PATCH style requests where a field being absent,
+// explicitly `null` or a value all have different meanings which an Option cannot distinguish.
+//
+// This implements the `json.Marshaler` and `json.Unmarshaler` interfaces,
+// where an absent field results in Undefined and `null` results in Null.
+// Both Undefined and Null are marshalled as `null`,
+// use the `omitzero` tag option to omit Undefined values.
+type Nullable[T any] struct {
+	value T
+	state nullableState
+}
+
+// IsUndefined returns true if the value is Undefined.
+func (n Nullable[T]) IsUndefined() bool {
+	return n.state == undefinedState
+}
+
+// IsNull returns true if the value is Null.
+func (n Nullable[T]) IsNull() bool {
+	return n.state == nullState
+}
+
+// IsValue returns true if the Nullable contains a value.
+func (n Nullable[T]) IsValue() bool {
+	return n.state == valueState
+}
+
+// IsZero returns true if the value is Undefined and is used by the JSON `omitzero` tag option.
+func (n Nullable[T]) IsZero() bool {
+	return n.state == undefinedState
+}
+
+// Unwrap returns the value if present or panics.
+func (n Nullable[T]) Unwrap() T {
+	if n.state != valueState {
+		panic("Nullable.Unwrap: nullable has no value")
+	}
+
+	return n.value
+}
+
+// UnwrapOr returns the contained value or provided default value.
+func (n Nullable[T]) UnwrapOr(value T) T {
+	if n.state == valueState {
+		return n.value
+	}
+
+	return value
+}
+
+// Option converts the Nullable into an Option,
+// mapping a value to Some and both Undefined and Null to None.
+func (n Nullable[T]) Option() Option[T] {
+	if n.state == valueState {
+		return Some(n.value)
+	}
+
+	return None[T]()
+}
+
+// MarshalJSON implements the `json.Marshaler` interface.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if n.state == valueState {
+		return json.Marshal(n.value)
+	}
+
+	return []byte("null"), nil
+}
+
+// UnmarshalJSON implements the `json.Unmarshaler` interface.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if len(data) == 4 && string(data[:4]) == "null" {
+		*n = Null[T]()
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*n = Value(v)
+	return nil
+}
+
+// applyTo sets the destination to the Nullable value, T, Option[T] or *T, or its zero value if Null.
+func (n Nullable[T]) applyTo(dst reflect.Value) error {
+	if dst.Type() == reflect.TypeOf(n) {
+		dst.Set(reflect.ValueOf(n))
+		return nil
+	}
+
+	if n.state == nullState {
+		dst.SetZero()
+		return nil
+	}
+
+	val := reflect.ValueOf(&n.value).Elem()
+	switch {
+	case val.Type().AssignableTo(dst.Type()):
+		dst.Set(val)
+	case dst.Type() == reflect.TypeOf(Option[T]{}):
+		dst.Set(reflect.ValueOf(Some(n.value)))
+	case dst.Kind() == reflect.Pointer && val.Type().AssignableTo(dst.Type().Elem()):
+		ptr := reflect.New(dst.Type().Elem())
+		ptr.Elem().Set(val)
+		dst.Set(ptr)
+	default:
+		return fmt.Errorf("cannot assign %s to %s", val.Type(), dst.Type())
+	}
+	return nil
+}
+
+// Undefined creates a Nullable that is Undefined.
+func Undefined[T any]() Nullable[T] {
+	return Nullable[T]{}
+}
+
+// Null creates a Nullable that is Null.
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{state: nullState}
+}
+
+// Value creates a Nullable with the given value.
+func Value[T any](value T) Nullable[T] {
+	return Nullable[T]{value: value, state: valueState}
+}
+
+// ApplyPatch applies the Nullable fields of the patch struct onto the dst struct fields with the same name.
+//
+// For each Nullable[T] field of the patch:
+//   - Undefined leaves the dst field untouched.
+//   - Null sets the dst field to its zero value eg. None, nil or the zero value of T.
+//   - A value sets the dst field, which can be of type T, Option[T], *T or Nullable[T].
+//
+// All other patch fields are ignored.
+// dst must be a non-nil pointer to a struct and patch a struct or pointer to a struct.
+func ApplyPatch(dst, patch any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return errors.New("optionext.ApplyPatch: dst must be a non-nil pointer to a struct")
+	}
+	dv = dv.Elem()
+
+	pv := reflect.Indirect(reflect.ValueOf(patch))
+	if pv.Kind() != reflect.Struct {
+		return errors.New("optionext.ApplyPatch: patch must be a struct or pointer to a struct")
+	}
+
+	pt := pv.Type()
+	for i := 0; i < pt.NumField(); i++ {
+		field := pt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		n, ok := pv.Field(i).Interface().(interface {
+			IsUndefined() bool
+			applyTo(reflect.Value) error
+		})
+		if !ok || n.IsUndefined() {
+			continue
+		}
+
+		df := dv.FieldByName(field.Name)
+		if !df.IsValid() || !df.CanSet() {
+			return fmt.Errorf("optionext.ApplyPatch: field %s not found in %s", field.Name, dv.Type())
+		}
+
+		if err := n.applyTo(df); err != nil {
+			return fmt.Errorf("optionext.ApplyPatch: field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package optionext
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestNullable(t *testing.T) {
+	u := Undefined[int]()
+	Equal(t, true, u.IsUndefined())
+	Equal(t, false, u.IsNull())
+	Equal(t, false, u.IsValue())
+	Equal(t, true, u.IsZero())
+	Equal(t, 3, u.UnwrapOr(3))
+	Equal(t, None[int](), u.Option())
+	PanicMatches(t, func() { u.Unwrap() }, "Nullable.Unwrap: nullable has no value")
+
+	n := Null[int]()
+	Equal(t, false, n.IsUndefined())
+	Equal(t, true, n.IsNull())
+	Equal(t, false, n.IsZero())
+	Equal(t, None[int](), n.Option())
+
+	v := Value(1)
+	Equal(t, true, v.IsValue())
+	Equal(t, false, v.IsZero())
+	Equal(t, 1, v.Unwrap())
+	Equal(t, Some(1), v.Option())
+}
+
+func TestNullableJSON(t *testing.T) {
+	type patch struct {
+		Name Nullable[string] `json:"name,omitzero"`
+		Age  Nullable[int]    `json:"age,omitzero"`
+		Nick Nullable[string] `json:"nick,omitzero"`
+		Opt  Option[string]   `json:"opt,omitzero"`
+	}
+
+	var p patch
+	Equal(t, json.Unmarshal([]byte(`{"name":"joeybloggs","age":null}`), &p), nil)
+	Equal(t, Value("joeybloggs"), p.Name)
+	Equal(t, Null[int](), p.Age)
+	Equal(t, Undefined[string](), p.Nick)
+
+	b, err := json.Marshal(p)
+	Equal(t, err, nil)
+	Equal(t, `{"name":"joeybloggs","age":null}`, string(b))
+
+	b, err = json.Marshal(patch{Opt: Some("opt")})
+	Equal(t, err, nil)
+	Equal(t, `{"opt":"opt"}`, string(b))
+
+	Equal(t, json.Unmarshal([]byte(`{"age":"bad"}`), &p) != nil, true)
+}
+
+func TestApplyPatch(t *testing.T) {
+	type user struct {
+		Name  string
+		Age   Option[int]
+		Email *string
+		Nick  Nullable[string]
+		Other string
+	}
+
+	type patch struct {
+		Name    Nullable[string]
+		Age     Nullable[int]
+		Email   Nullable[string]
+		Nick    Nullable[string]
+		Other   string
+		ignored Nullable[string]
+	}
+
+	email := "old@example.com"
+	u := user{Name: "old", Age: Some(30), Email: &email, Nick: Value("nick"), Other: "other"}
+	Equal(t, ApplyPatch(&u, patch{
+		Name:  Value("new"),
+		Age:   Null[int](),
+		Email: Value("new@example.com"),
+		Other: "ignored",
+	}), nil)
+	Equal(t, "new", u.Name)
+	Equal(t, None[int](), u.Age)
+	Equal(t, "new@example.com", *u.Email)
+	Equal(t, Value("nick"), u.Nick)
+	Equal(t, "other", u.Other)
+
+	Equal(t, ApplyPatch(&u, &patch{Age: Value(31), Email: Null[string](), Nick: Null[string]()}), nil)
+	Equal(t, Some(31), u.Age)
+	Equal(t, true, u.Email == nil)
+	Equal(t, Null[string](), u.Nick)
+
+	Equal(t, ApplyPatch(&u, patch{Nick: Value("new")}), nil)
+	Equal(t, Value("new"), u.Nick)
+
+	type badPatch struct {
+		Name    Nullable[int]
+		Missing Nullable[int]
+	}
+	NotEqual(t, ApplyPatch(&u, badPatch{Name: Value(1)}), nil)
+	NotEqual(t, ApplyPatch(&u, badPatch{Missing: Value(1)}), nil)
+	NotEqual(t, ApplyPatch(u, patch{}), nil)
+	NotEqual(t, ApplyPatch(&u, 1), nil)
+}
@@ -12,19 +12,22 @@ import "encoding/json"
 // It supports:
 // - String
 // - Bool
-// - Uint8
-// - Float64
-// - Int16
-// - Int32
-// - Int64
+// - All Int, Uint and Float kinds - with overflow checks.
 // - interface{}/any
 // - time.Time
+// - Fixed size byte arrays eg. [16]byte - stored as []byte.
+// - encoding.TextMarshaler and encoding.TextUnmarshaler types eg. netip.Addr - stored as string.
+// - Types registered using RegisterSQLType.
 // - Struct - when type is convertable to []byte and assumes JSON.
 // - Slice - when type is convertable to []byte and assumes JSON.
 // - Map types - when type is convertable to []byte and assumes JSON.
 //
 // This also implements the `json.Marshaler` and `json.Unmarshaler` interfaces.
 // The only caveat is a None value will result in a JSON `null` value.
+//
+// It also implements the `encoding.TextMarshaler`, `encoding.TextUnmarshaler`,
+// `xml.Marshaler` and `xml.Unmarshaler` interfaces,
+// where a None value is omitted from XML and empty text results in a None value.
 type Option[T any] struct {
 	value  T
 	isSome bool
@@ -40,6 +43,11 @@ func (o Option[T]) IsNone() bool {
 	return !o.isSome
 }
 
+// IsZero returns true if the option is empty and is used by the JSON `omitzero` tag option.
+func (o Option[T]) IsZero() bool {
+	return !o.isSome
+}
+
 // MarshalJSON implements the `json.Marshaler` interface.
 func (o Option[T]) MarshalJSON() ([]byte, error) {
 	if o.isSome {
@@ -101,6 +109,15 @@ func (o Option[T]) Unwrap() T {
 	return o.value
 }
 
+// Expect returns the value if the option is not empty or panics with the provided message.
+func (o Option[T]) Expect(msg string) T {
+	if !o.isSome {
+		panic(msg)
+	}
+
+	return o.value
+}
+
 // UnwrapOr returns the contained `Some` value or provided default value.
 //
 // Arguments passed to `UnwrapOr` are eagerly evaluated.
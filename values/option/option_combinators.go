@@ -0,0 +1,135 @@
+package optionext
+
+import "iter"
+
+// Map maps an Option[T] to Option[U] by applying the provided function to the contained value,
+// returns None otherwise.
+func Map[T, U any](o Option[T], fn func(T) U) Option[U] {
+	if o.isSome {
+		return Some(fn(o.value))
+	}
+
+	return None[U]()
+}
+
+// FlatMap calls the provided function with the contained value if the option is Some,
+// returns None otherwise.
+//
+// This differs from `Map` in that the provided function returns an Option[U] allowing
+// the function to return None.
+func FlatMap[T, U any](o Option[T], fn func(T) Option[U]) Option[U] {
+	if o.isSome {
+		return fn(o.value)
+	}
+
+	return None[U]()
+}
+
+// Zip combines the values of both options using the provided function if both are Some,
+// returns None otherwise.
+func Zip[T, U, V any](a Option[T], b Option[U], fn func(T, U) V) Option[V] {
+	if a.isSome && b.isSome {
+		return Some(fn(a.value, b.value))
+	}
+
+	return None[V]()
+}
+
+// FromPtr converts a pointer into an Option,
+// mapping nil to None and all else to Some with the pointed to value.
+func FromPtr[T any](ptr *T) Option[T] {
+	if ptr == nil {
+		return None[T]()
+	}
+
+	return Some(*ptr)
+}
+
+// FromComma converts the comma ok idiom, as commonly returned by Go map lookups and type assertions,
+// into an Option.
+func FromComma[T any](value T, ok bool) Option[T] {
+	if ok {
+		return Some(value)
+	}
+
+	return None[T]()
+}
+
+// ToPtr converts the Option into a pointer to a copy of the contained value or nil if None.
+func (o Option[T]) ToPtr() *T {
+	if o.isSome {
+		v := o.value
+		return &v
+	}
+
+	return nil
+}
+
+// Filter returns the option if it is Some and the provided function returns true,
+// returns None otherwise.
+func (o Option[T]) Filter(fn func(T) bool) Option[T] {
+	if o.isSome && fn(o.value) {
+		return o
+	}
+
+	return None[T]()
+}
+
+// Or returns the option if it is Some, otherwise returns the provided option.
+//
+// Arguments passed to `Or` are eagerly evaluated.
+// If passing the result of a function call,
+// look to use `OrElse`, which is evaluated lazily.
+func (o Option[T]) Or(other Option[T]) Option[T] {
+	if o.isSome {
+		return o
+	}
+
+	return other
+}
+
+// OrElse returns the option if it is Some, otherwise calls the provided function and returns its result.
+func (o Option[T]) OrElse(fn func() Option[T]) Option[T] {
+	if o.isSome {
+		return o
+	}
+
+	return fn()
+}
+
+// Xor returns Some if exactly one of the option and the provided option is Some, returns None otherwise.
+func (o Option[T]) Xor(other Option[T]) Option[T] {
+	if o.isSome && !other.isSome {
+		return o
+	}
+
+	if !o.isSome && other.isSome {
+		return other
+	}
+
+	return None[T]()
+}
+
+// Take takes the value out of the option, leaving None in its place.
+func (o *Option[T]) Take() Option[T] {
+	taken := *o
+	*o = None[T]()
+	return taken
+}
+
+// Replace replaces the value in the option with the provided value, returning the old option.
+func (o *Option[T]) Replace(value T) Option[T] {
+	old := *o
+	*o = Some(value)
+	return old
+}
+
+// All returns an iterator yielding the contained value if the option is Some,
+// yields nothing otherwise.
+func (o Option[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if o.isSome {
+			yield(o.value)
+		}
+	}
+}
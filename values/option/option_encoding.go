@@ -0,0 +1,150 @@
+package optionext
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strconv"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// MarshalText implements the `encoding.TextMarshaler` interface.
+//
+// This honours the `encoding.TextMarshaler` interface if the value implements it,
+// formats strings, bools and numbers using strconv and treats all else as JSON.
+// A None value results in empty text.
+func (o Option[T]) MarshalText() ([]byte, error) {
+	if o.IsNone() {
+		return []byte{}, nil
+	}
+
+	val := reflect.ValueOf(&o.value).Elem()
+	if val.Type().Implements(textMarshalerType) {
+		return val.Interface().(encoding.TextMarshaler).MarshalText()
+	}
+
+	switch val.Kind() {
+	case reflect.String:
+		return []byte(val.String()), nil
+	case reflect.Bool:
+		return strconv.AppendBool(nil, val.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, val.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(nil, val.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, val.Float(), 'g', -1, val.Type().Bits()), nil
+	default:
+		return json.Marshal(o.value)
+	}
+}
+
+// UnmarshalText implements the `encoding.TextUnmarshaler` interface.
+//
+// This honours the `encoding.TextUnmarshaler` interface if the value implements it,
+// parses strings, bools and numbers using strconv and treats all else as JSON.
+// Empty text results in a None value, including for Option[string].
+func (o *Option[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*o = None[T]()
+		return nil
+	}
+
+	var v T
+	ptr := reflect.ValueOf(&v)
+	if ptr.Type().Implements(textUnmarshalerType) {
+		if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+			return err
+		}
+		*o = Some(v)
+		return nil
+	}
+
+	val := ptr.Elem()
+	switch val.Kind() {
+	case reflect.String:
+		val.SetString(string(text))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(string(text))
+		if err != nil {
+			return err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(string(text), 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(string(text), 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(string(text), val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetFloat(f)
+	default:
+		if err := json.Unmarshal(text, &v); err != nil {
+			return err
+		}
+	}
+
+	*o = Some(v)
+	return nil
+}
+
+// MarshalXML implements the `xml.Marshaler` interface.
+//
+// A None value results in the element being omitted.
+func (o Option[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if o.IsNone() {
+		return nil
+	}
+
+	return e.EncodeElement(o.value, start)
+}
+
+// UnmarshalXML implements the `xml.Unmarshaler` interface.
+//
+// A present element results in a Some value, even if empty,
+// while an absent element leaves the Option as None.
+func (o *Option[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v T
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+
+	*o = Some(v)
+	return nil
+}
+
+// MarshalXMLAttr implements the `xml.MarshalerAttr` interface.
+//
+// A None value results in the attribute being omitted.
+func (o Option[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if o.IsNone() {
+		return xml.Attr{}, nil
+	}
+
+	text, err := o.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements the `xml.UnmarshalerAttr` interface.
+func (o *Option[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return o.UnmarshalText([]byte(attr.Value))
+}
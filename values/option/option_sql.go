@@ -3,10 +3,13 @@ package optionext
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
+	"sync"
 	"time"
 )
 
@@ -19,8 +22,44 @@ var (
 	int64Type     = reflect.TypeOf((*int64)(nil)).Elem()
 	float64Type   = reflect.TypeOf((*float64)(nil)).Elem()
 	boolType      = reflect.TypeOf((*bool)(nil)).Elem()
+
+	sqlTypesMu sync.RWMutex
+	sqlTypes   = make(map[reflect.Type]sqlTypeFuncs)
 )
 
+type sqlTypeFuncs struct {
+	scan  func(src any) (any, error)
+	value func(v any) (driver.Value, error)
+}
+
+// RegisterSQLType registers the functions used by Option[T] to scan and value the type T,
+// taking precedence over the default behaviour but not over T implementing `sql.Scanner` or `driver.Valuer`.
+//
+// This allows teaching Option driver specific types, such as Postgres arrays, without wrapping them.
+// The scan function is never called with a nil src, as that results in None.
+//
+// Registering the same type again replaces the previously registered functions.
+func RegisterSQLType[T any](scan func(src any) (T, error), value func(v T) (driver.Value, error)) {
+	sqlTypesMu.Lock()
+	defer sqlTypesMu.Unlock()
+
+	sqlTypes[reflect.TypeOf((*T)(nil)).Elem()] = sqlTypeFuncs{
+		scan: func(src any) (any, error) {
+			return scan(src)
+		},
+		value: func(v any) (driver.Value, error) {
+			return value(v.(T))
+		},
+	}
+}
+
+func lookupSQLType(typ reflect.Type) (funcs sqlTypeFuncs, found bool) {
+	sqlTypesMu.RLock()
+	funcs, found = sqlTypes[typ]
+	sqlTypesMu.RUnlock()
+	return
+}
+
 // Scan implements the sql.Scanner interface.
 func (o *Option[T]) Scan(value any) error {
 	if value == nil {
@@ -38,6 +77,33 @@ func (o *Option[T]) Scan(value any) error {
 		return nil
 	}
 
+	if funcs, found := lookupSQLType(val.Type().Elem()); found {
+		v, err := funcs.scan(value)
+		if err != nil {
+			return err
+		}
+		*o = Some(v.(T))
+		return nil
+	}
+
+	if val.Type().Implements(textUnmarshalerType) && val.Elem().Type() != timeType {
+		var text []byte
+		switch v := value.(type) {
+		case string:
+			text = []byte(v)
+		case []byte:
+			text = v
+		}
+		if text != nil {
+			var v T
+			if err := any(&v).(encoding.TextUnmarshaler).UnmarshalText(text); err != nil {
+				return err
+			}
+			*o = Some(v)
+			return nil
+		}
+	}
+
 	val = val.Elem()
 	switch val.Kind() {
 	case reflect.String:
@@ -52,69 +118,56 @@ func (o *Option[T]) Scan(value any) error {
 			return err
 		}
 		*o = Some(reflect.ValueOf(v.Bool).Convert(val.Type()).Interface().(T))
-	case reflect.Uint8:
-		var v sql.NullByte
-		if err := v.Scan(value); err != nil {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := scanUint(value)
+		if err != nil {
 			return err
 		}
-		*o = Some(reflect.ValueOf(v.Byte).Convert(val.Type()).Interface().(T))
-	case reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
-		v := reflect.ValueOf(value)
-		if v.Type().ConvertibleTo(val.Type()) {
-			*o = Some(reflect.ValueOf(v.Convert(val.Type()).Interface()).Interface().(T))
-		} else {
-			return fmt.Errorf("value %T not convertable to %T", value, o.value)
+		if val.OverflowUint(u) {
+			return fmt.Errorf("value %d out of range for %s", u, val.Kind())
 		}
-	case reflect.Float32:
+		*o = Some(reflect.ValueOf(u).Convert(val.Type()).Interface().(T))
+	case reflect.Float32, reflect.Float64:
 		var v sql.NullFloat64
 		if err := v.Scan(value); err != nil {
 			return err
 		}
-		*o = Some(reflect.ValueOf(v.Float64).Convert(val.Type()).Interface().(T))
-	case reflect.Float64:
-		var v sql.NullFloat64
-		if err := v.Scan(value); err != nil {
-			return err
+		if val.OverflowFloat(v.Float64) {
+			return fmt.Errorf("value %g out of range for %s", v.Float64, val.Kind())
 		}
 		*o = Some(reflect.ValueOf(v.Float64).Convert(val.Type()).Interface().(T))
-	case reflect.Int:
-		var v sql.NullInt64
-		if err := v.Scan(value); err != nil {
-			return err
-		}
-		if v.Int64 > math.MaxInt || v.Int64 < math.MinInt {
-			return fmt.Errorf("value %d out of range for int", v.Int64)
-		}
-		*o = Some(reflect.ValueOf(v.Int64).Convert(val.Type()).Interface().(T))
-	case reflect.Int8:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		var v sql.NullInt64
 		if err := v.Scan(value); err != nil {
 			return err
 		}
-		if v.Int64 > math.MaxInt8 || v.Int64 < math.MinInt8 {
-			return fmt.Errorf("value %d out of range for int8", v.Int64)
+		if val.OverflowInt(v.Int64) {
+			return fmt.Errorf("value %d out of range for %s", v.Int64, val.Kind())
 		}
 		*o = Some(reflect.ValueOf(v.Int64).Convert(val.Type()).Interface().(T))
-	case reflect.Int16:
-		var v sql.NullInt16
-		if err := v.Scan(value); err != nil {
-			return err
+	case reflect.Interface:
+		*o = Some(reflect.ValueOf(value).Convert(val.Type()).Interface().(T))
+	case reflect.Array:
+		if val.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", value, o.value)
 		}
-		*o = Some(reflect.ValueOf(v.Int16).Convert(val.Type()).Interface().(T))
-	case reflect.Int32:
-		var v sql.NullInt32
-		if err := v.Scan(value); err != nil {
-			return err
+
+		var b []byte
+		switch v := value.(type) {
+		case []byte:
+			b = v
+		case string:
+			b = []byte(v)
+		default:
+			return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", value, o.value)
 		}
-		*o = Some(reflect.ValueOf(v.Int32).Convert(val.Type()).Interface().(T))
-	case reflect.Int64:
-		var v sql.NullInt64
-		if err := v.Scan(value); err != nil {
-			return err
+		if len(b) != val.Len() {
+			return fmt.Errorf("value of length %d does not fit into type %T", len(b), o.value)
 		}
-		*o = Some(reflect.ValueOf(v.Int64).Convert(val.Type()).Interface().(T))
-	case reflect.Interface:
-		*o = Some(reflect.ValueOf(value).Convert(val.Type()).Interface().(T))
+
+		var v T
+		reflect.Copy(reflect.ValueOf(&v).Elem(), reflect.ValueOf(b))
+		*o = Some(v)
 	case reflect.Struct:
 		if val.CanConvert(timeType) {
 			switch t := value.(type) {
@@ -165,7 +218,8 @@ func (o *Option[T]) Scan(value any) error {
 
 // Value implements the driver.Valuer interface.
 //
-// This honours the `driver.Valuer` interface if the value implements it.
+// This honours the `driver.Valuer` interface if the value implements it,
+// followed by types registered using RegisterSQLType and the `encoding.TextMarshaler` interface.
 // It also supports custom types of the std types and treats all else as []byte.
 func (o Option[T]) Value() (driver.Value, error) {
 	if o.IsNone() {
@@ -177,6 +231,18 @@ func (o Option[T]) Value() (driver.Value, error) {
 		return val.Interface().(driver.Valuer).Value()
 	}
 
+	if funcs, found := lookupSQLType(val.Type()); found {
+		return funcs.value(o.value)
+	}
+
+	if val.Type() != timeType && val.Type().Implements(textMarshalerType) {
+		b, err := val.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+
 	switch val.Kind() {
 	case reflect.String:
 		return val.Convert(stringType).Interface(), nil
@@ -184,12 +250,23 @@ func (o Option[T]) Value() (driver.Value, error) {
 		return val.Convert(boolType).Interface(), nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return val.Convert(int64Type).Interface(), nil
-	case reflect.Float64:
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := val.Uint()
+		if u > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d out of range for int64", u)
+		}
+		return int64(u), nil
+	case reflect.Float32, reflect.Float64:
 		return val.Convert(float64Type).Interface(), nil
 	case reflect.Slice, reflect.Array:
 		if val.Type().ConvertibleTo(byteSliceType) {
 			return val.Convert(byteSliceType).Interface(), nil
 		}
+		if val.Kind() == reflect.Array && val.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, val.Len())
+			reflect.Copy(reflect.ValueOf(b), val)
+			return b, nil
+		}
 		return json.Marshal(val.Interface())
 	case reflect.Struct:
 		if val.CanConvert(timeType) {
@@ -202,3 +279,30 @@ func (o Option[T]) Value() (driver.Value, error) {
 		return o.value, nil
 	}
 }
+
+// scanUint converts the driver value into an uint64.
+func scanUint(value any) (uint64, error) {
+	switch v := value.(type) {
+	case int64:
+		if v < 0 {
+			return 0, fmt.Errorf("value %d out of range for uint64", v)
+		}
+		return uint64(v), nil
+	case []byte:
+		return strconv.ParseUint(string(v), 10, 64)
+	case string:
+		return strconv.ParseUint(v, 10, 64)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() < 0 {
+			return 0, fmt.Errorf("value %d out of range for uint64", rv.Int())
+		}
+		return uint64(rv.Int()), nil
+	}
+	return 0, fmt.Errorf("value %T not convertable to uint64", value)
+}
@@ -2,9 +2,15 @@ package optionext
 
 import (
 	"database/sql/driver"
+	"encoding"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"math"
+	"net/netip"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -82,6 +88,81 @@ func TestUnwraps(t *testing.T) {
 	Equal(t, nil, v2)
 }
 
+func TestMapXXX(t *testing.T) {
+	Equal(t, Some("1"), Map(Some(1), strconv.Itoa))
+	Equal(t, None[string](), Map(None[int](), strconv.Itoa))
+
+	toString := func(i int) Option[string] { return Some(strconv.Itoa(i)) }
+	Equal(t, Some("1"), FlatMap(Some(1), toString))
+	Equal(t, None[string](), FlatMap(None[int](), toString))
+	Equal(t, None[string](), FlatMap(Some(1), func(int) Option[string] { return None[string]() }))
+
+	add := func(a int, b string) string { return strconv.Itoa(a) + b }
+	Equal(t, Some("1a"), Zip(Some(1), Some("a"), add))
+	Equal(t, None[string](), Zip(None[int](), Some("a"), add))
+	Equal(t, None[string](), Zip(Some(1), None[string](), add))
+}
+
+func TestOrXXX(t *testing.T) {
+	isOdd := func(i int) bool { return i%2 == 1 }
+	Equal(t, Some(1), Some(1).Filter(isOdd))
+	Equal(t, None[int](), Some(2).Filter(isOdd))
+	Equal(t, None[int](), None[int]().Filter(isOdd))
+
+	Equal(t, Some(1), Some(1).Or(Some(2)))
+	Equal(t, Some(2), None[int]().Or(Some(2)))
+	Equal(t, None[int](), None[int]().Or(None[int]()))
+
+	Equal(t, Some(1), Some(1).OrElse(func() Option[int] { return Some(2) }))
+	Equal(t, Some(2), None[int]().OrElse(func() Option[int] { return Some(2) }))
+
+	Equal(t, Some(1), Some(1).Xor(None[int]()))
+	Equal(t, Some(2), None[int]().Xor(Some(2)))
+	Equal(t, None[int](), Some(1).Xor(Some(2)))
+	Equal(t, None[int](), None[int]().Xor(None[int]()))
+}
+
+func TestTakeReplace(t *testing.T) {
+	o := Some(1)
+	Equal(t, Some(1), o.Take())
+	Equal(t, None[int](), o)
+	Equal(t, None[int](), o.Take())
+
+	Equal(t, None[int](), o.Replace(2))
+	Equal(t, Some(2), o)
+	Equal(t, Some(2), o.Replace(3))
+	Equal(t, Some(3), o)
+}
+
+func TestConversions(t *testing.T) {
+	i := 1
+	Equal(t, Some(1), FromPtr(&i))
+	Equal(t, None[int](), FromPtr[int](nil))
+
+	ptr := Some(1).ToPtr()
+	Equal(t, 1, *ptr)
+	Equal(t, true, None[int]().ToPtr() == nil)
+
+	m := map[string]int{"one": 1}
+	v, ok := m["one"]
+	Equal(t, Some(1), FromComma(v, ok))
+	v, ok = m["two"]
+	Equal(t, None[int](), FromComma(v, ok))
+}
+
+func TestAll(t *testing.T) {
+	var values []int
+	for v := range Some(1).All() {
+		values = append(values, v)
+	}
+	Equal(t, values, []int{1})
+
+	for v := range None[int]().All() {
+		values = append(values, v)
+	}
+	Equal(t, values, []int{1})
+}
+
 func TestNilOption(t *testing.T) {
 	value := Some[any](nil)
 	Equal(t, false, value.IsNone())
@@ -149,6 +230,94 @@ func TestOptionJSONOmitempty(t *testing.T) {
 	Equal(t, `{}`, string(b))
 }
 
+func TestOptionText(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    encoding.TextMarshaler
+		expected string
+	}{
+		{name: "none", value: None[int](), expected: ""},
+		{name: "string", value: Some("value"), expected: "value"},
+		{name: "custom string", value: Some(customStringType("custom")), expected: "custom"},
+		{name: "bool", value: Some(true), expected: "true"},
+		{name: "int8", value: Some(int8(-8)), expected: "-8"},
+		{name: "uint64", value: Some(uint64(64)), expected: "64"},
+		{name: "float32", value: Some(float32(1.5)), expected: "1.5"},
+		{name: "time", value: Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), expected: "2024-01-02T03:04:05Z"},
+		{name: "struct", value: Some(testStructType{Name: "test"}), expected: `{"Name":"test"}`},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.value.MarshalText()
+			Equal(t, err, nil)
+			Equal(t, string(b), tc.expected)
+		})
+	}
+
+	var s Option[string]
+	Equal(t, s.UnmarshalText([]byte("value")), nil)
+	Equal(t, Some("value"), s)
+	Equal(t, s.UnmarshalText([]byte("")), nil)
+	Equal(t, None[string](), s)
+
+	var i8 Option[int8]
+	Equal(t, i8.UnmarshalText([]byte("-8")), nil)
+	Equal(t, Some(int8(-8)), i8)
+	NotEqual(t, i8.UnmarshalText([]byte("128")), nil)
+
+	var u Option[uint16]
+	Equal(t, u.UnmarshalText([]byte("16")), nil)
+	Equal(t, Some(uint16(16)), u)
+
+	var f Option[float64]
+	Equal(t, f.UnmarshalText([]byte("1.5")), nil)
+	Equal(t, Some(1.5), f)
+
+	var b Option[bool]
+	Equal(t, b.UnmarshalText([]byte("true")), nil)
+	Equal(t, Some(true), b)
+	NotEqual(t, b.UnmarshalText([]byte("maybe")), nil)
+
+	var tm Option[time.Time]
+	Equal(t, tm.UnmarshalText([]byte("2024-01-02T03:04:05Z")), nil)
+	Equal(t, Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), tm)
+
+	var st Option[testStructType]
+	Equal(t, st.UnmarshalText([]byte(`{"Name":"test"}`)), nil)
+	Equal(t, Some(testStructType{Name: "test"}), st)
+}
+
+func TestOptionXML(t *testing.T) {
+	type s struct {
+		XMLName xml.Name       `xml:"s"`
+		ID      Option[int]    `xml:"id,attr"`
+		Name    Option[string] `xml:"name"`
+		Age     Option[int]    `xml:"age"`
+	}
+
+	b, err := xml.Marshal(s{ID: Some(1), Name: Some("joeybloggs")})
+	Equal(t, err, nil)
+	Equal(t, string(b), `<s id="1"><name>joeybloggs</name></s>`)
+
+	b, err = xml.Marshal(s{})
+	Equal(t, err, nil)
+	Equal(t, string(b), `<s></s>`)
+
+	var v s
+	Equal(t, xml.Unmarshal([]byte(`<s id="1"><name>joeybloggs</name><age>3</age></s>`), &v), nil)
+	Equal(t, Some(1), v.ID)
+	Equal(t, Some("joeybloggs"), v.Name)
+	Equal(t, Some(3), v.Age)
+
+	v = s{}
+	Equal(t, xml.Unmarshal([]byte(`<s><name></name></s>`), &v), nil)
+	Equal(t, None[int](), v.ID)
+	Equal(t, Some(""), v.Name)
+	Equal(t, None[int](), v.Age)
+}
+
 func TestSQLDriverValue(t *testing.T) {
 	var v valueTest
 	Equal(t, reflect.TypeOf(v).Implements(valuerType), true)
@@ -333,7 +502,22 @@ func TestSQLScanner(t *testing.T) {
 	Equal(t, optionUint, Some(uint(200)))
 
 	err = optionUint64.Scan("200")
-	Equal(t, err.Error(), "value string not convertable to uint64")
+	Equal(t, err, nil)
+	Equal(t, optionUint64, Some(uint64(200)))
+
+	err = optionUint64.Scan("blah")
+	NotEqual(t, err, nil)
+
+	err = optionUint32.Scan([]byte("300"))
+	Equal(t, err, nil)
+	Equal(t, optionUint32, Some(uint32(300)))
+
+	err = optionUint8.Scan(int64(256))
+	Equal(t, err.Error(), "value 256 out of range for uint8")
+	Equal(t, optionUint8, Some(uint8(200)))
+
+	err = optionUint16.Scan(int64(-1))
+	Equal(t, err.Error(), "value -1 out of range for uint64")
 
 	err = optionI64.Scan(value)
 	Equal(t, err, nil)
@@ -372,8 +556,15 @@ func TestSQLScanner(t *testing.T) {
 	Equal(t, optionF32, Some(float32(2.0)))
 
 	err = optionF32.Scan(math.MaxFloat64)
+	Equal(t, err.Error(), "value 1.7976931348623157e+308 out of range for float32")
+	Equal(t, optionF32, Some(float32(2.0)))
+
+	err = optionI16.Scan("12")
 	Equal(t, err, nil)
-	Equal(t, optionF32, Some(float32(math.Inf(1))))
+	Equal(t, optionI16, Some(int16(12)))
+
+	err = optionI16.Scan(int64(math.MaxInt32))
+	Equal(t, err.Error(), "value 2147483647 out of range for int16")
 
 	err = optionF64.Scan(2.0)
 	Equal(t, err, nil)
@@ -460,6 +651,94 @@ func TestSQLScanner(t *testing.T) {
 	Equal(t, true, string(optionRawMessage.Unwrap()) == string([]byte{4, 5, 6}))
 }
 
+func TestSQLExtendedTypes(t *testing.T) {
+	// unsigned and float32 values
+	v, err := Some(uint32(7)).Value()
+	Equal(t, err, nil)
+	Equal(t, v, int64(7))
+
+	_, err = Some(uint64(math.MaxUint64)).Value()
+	Equal(t, err.Error(), "value 18446744073709551615 out of range for int64")
+
+	v, err = Some(float32(1.5)).Value()
+	Equal(t, err, nil)
+	Equal(t, v, float64(1.5))
+
+	// fixed byte arrays
+	id := [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	v, err = Some(id).Value()
+	Equal(t, err, nil)
+	Equal(t, v, id[:])
+
+	var optionID Option[[16]byte]
+	Equal(t, optionID.Scan(id[:]), nil)
+	Equal(t, optionID, Some(id))
+
+	err = optionID.Scan([]byte{1, 2, 3})
+	Equal(t, err.Error(), "value of length 3 does not fit into type [16]uint8")
+	Equal(t, optionID, Some(id))
+
+	// text marshalers
+	addr := netip.MustParseAddr("192.168.0.1")
+	v, err = Some(addr).Value()
+	Equal(t, err, nil)
+	Equal(t, v, "192.168.0.1")
+
+	var optionAddr Option[netip.Addr]
+	Equal(t, optionAddr.Scan("192.168.0.1"), nil)
+	Equal(t, optionAddr, Some(addr))
+
+	Equal(t, optionAddr.Scan([]byte("::1")), nil)
+	Equal(t, optionAddr, Some(netip.MustParseAddr("::1")))
+
+	NotEqual(t, optionAddr.Scan("bad"), nil)
+}
+
+type testIntArray []int64
+
+func TestRegisterSQLType(t *testing.T) {
+	RegisterSQLType(
+		func(src any) (testIntArray, error) {
+			s, ok := src.(string)
+			if !ok {
+				return nil, errors.New("bad type")
+			}
+
+			var arr testIntArray
+			for _, part := range strings.Split(strings.Trim(s, "{}"), ",") {
+				i, err := strconv.ParseInt(part, 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, i)
+			}
+			return arr, nil
+		},
+		func(v testIntArray) (driver.Value, error) {
+			parts := make([]string, len(v))
+			for i, n := range v {
+				parts[i] = strconv.FormatInt(n, 10)
+			}
+			return "{" + strings.Join(parts, ",") + "}", nil
+		},
+	)
+
+	var arr Option[testIntArray]
+	Equal(t, arr.Scan("{1,2,3}"), nil)
+	Equal(t, arr, Some(testIntArray{1, 2, 3}))
+
+	err := arr.Scan(1)
+	Equal(t, err.Error(), "bad type")
+
+	v, err := arr.Value()
+	Equal(t, err, nil)
+	Equal(t, v, "{1,2,3}")
+
+	v, err = None[testIntArray]().Value()
+	Equal(t, err, nil)
+	Equal(t, v, nil)
+}
+
 func BenchmarkOption(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		opt := returnTypedSomeOption()
@@ -528,3 +807,8 @@ func returnTypedNoOption() *testStruct {
 func returnNoOptionNil() (any, bool) {
 	return nil, true
 }
+
+func TestExpect(t *testing.T) {
+	Equal(t, Some(1).Expect("missing"), 1)
+	PanicMatches(t, func() { None[int]().Expect("missing value") }, "missing value")
+}
@@ -1,5 +1,7 @@
 package resultext
 
+import "fmt"
+
 // Result represents the result of an operation that is successful or not.
 type Result[T, E any] struct {
 	ok   T
@@ -17,6 +19,15 @@ func (r Result[T, E]) Unwrap() T {
 	panic("Result.Unwrap(): result is Err")
 }
 
+// Expect returns the contained Ok value or panics with the provided message and the Err value.
+func (r Result[T, E]) Expect(msg string) T {
+	if r.isOk {
+		return r.ok
+	}
+
+	panic(fmt.Sprintf("%s: %v", msg, r.err))
+}
+
 // UnwrapOr returns the contained Ok value or a provided default.
 //
 // Arguments passed to UnwrapOr are evaluated lazily.
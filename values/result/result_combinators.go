@@ -0,0 +1,195 @@
+package resultext
+
+import (
+	"errors"
+
+	optionext "github.com/pchchv/extender/values/option"
+)
+
+// Map maps a Result[T, E] to Result[U, E] by applying the provided function to the contained Ok value,
+// leaving an Err value untouched.
+func Map[T, U, E any](r Result[T, E], fn func(T) U) Result[U, E] {
+	if r.isOk {
+		return Ok[U, E](fn(r.ok))
+	}
+
+	return Err[U](r.err)
+}
+
+// MapErr maps a Result[T, E] to Result[T, F] by applying the provided function to the contained Err value,
+// leaving an Ok value untouched.
+func MapErr[T, E, F any](r Result[T, E], fn func(E) F) Result[T, F] {
+	if r.isOk {
+		return Ok[T, F](r.ok)
+	}
+
+	return Err[T](fn(r.err))
+}
+
+// FlatMap calls the provided function with the contained Ok value,
+// returns the Err value otherwise.
+//
+// This differs from `Map` in that the provided function returns a Result[U, E] allowing
+// the function to fail.
+func FlatMap[T, U, E any](r Result[T, E], fn func(T) Result[U, E]) Result[U, E] {
+	if r.isOk {
+		return fn(r.ok)
+	}
+
+	return Err[U](r.err)
+}
+
+// Or returns the provided result if the result is Err, otherwise returns the Ok value of the result.
+//
+// Arguments passed to `Or` are eagerly evaluated.
+// If passing the result of a function call,
+// look to use `OrElse`, which is evaluated lazily.
+func Or[T, E, F any](r Result[T, E], other Result[T, F]) Result[T, F] {
+	if r.isOk {
+		return Ok[T, F](r.ok)
+	}
+
+	return other
+}
+
+// OrElse calls the provided function with the Err value if the result is Err,
+// otherwise returns the Ok value of the result.
+func OrElse[T, E, F any](r Result[T, E], fn func(E) Result[T, F]) Result[T, F] {
+	if r.isOk {
+		return Ok[T, F](r.ok)
+	}
+
+	return fn(r.err)
+}
+
+// Flatten converts from Result[Result[T, E], E] to Result[T, E].
+func Flatten[T, E any](r Result[Result[T, E], E]) Result[T, E] {
+	if r.isOk {
+		return r.ok
+	}
+
+	return Err[T](r.err)
+}
+
+// Zip combines the Ok values of both results using the provided function,
+// returning the first Err value encountered otherwise.
+func Zip[T, U, V, E any](a Result[T, E], b Result[U, E], fn func(T, U) V) Result[V, E] {
+	if !a.isOk {
+		return Err[V](a.err)
+	}
+
+	if !b.isOk {
+		return Err[V](b.err)
+	}
+
+	return Ok[V, E](fn(a.ok, b.ok))
+}
+
+// Collect converts a slice of results into a result of a slice containing all Ok values,
+// returning the first Err value encountered otherwise.
+func Collect[T, E any](results []Result[T, E]) Result[[]T, E] {
+	values := make([]T, 0, len(results))
+	for _, r := range results {
+		if !r.isOk {
+			return Err[[]T](r.err)
+		}
+		values = append(values, r.ok)
+	}
+
+	return Ok[[]T, E](values)
+}
+
+// CollectAll converts a slice of results into a result of a slice containing all Ok values,
+// returning all Err values encountered joined using `errors.Join` otherwise.
+func CollectAll[T any](results []Result[T, error]) Result[[]T, error] {
+	var errs []error
+	values := make([]T, 0, len(results))
+	for _, r := range results {
+		if !r.isOk {
+			errs = append(errs, r.err)
+			continue
+		}
+		values = append(values, r.ok)
+	}
+
+	if len(errs) > 0 {
+		return Err[[]T](errors.Join(errs...))
+	}
+
+	return Ok[[]T, error](values)
+}
+
+// From converts the provided value and error, as commonly returned by Go functions, into a Result.
+// It returns Err if the error is not nil, otherwise Ok with the value.
+func From[T any](value T, err error) Result[T, error] {
+	if err != nil {
+		return Err[T](err)
+	}
+
+	return Ok[T, error](value)
+}
+
+// OkOr converts the Option into a Result,
+// mapping Some(v) to Ok(v) and None to Err(err).
+//
+// Arguments passed to `OkOr` are eagerly evaluated.
+// If passing the result of a function call,
+// look to use `OkOrElse`, which is evaluated lazily.
+func OkOr[T, E any](o optionext.Option[T], err E) Result[T, E] {
+	if o.IsSome() {
+		return Ok[T, E](o.Unwrap())
+	}
+
+	return Err[T](err)
+}
+
+// OkOrElse converts the Option into a Result,
+// mapping Some(v) to Ok(v) and None to Err(fn()).
+func OkOrElse[T, E any](o optionext.Option[T], fn func() E) Result[T, E] {
+	if o.IsSome() {
+		return Ok[T, E](o.Unwrap())
+	}
+
+	return Err[T](fn())
+}
+
+// Unpack returns the Ok value and Err value of the result, as commonly returned by Go functions.
+func (r Result[T, E]) Unpack() (T, E) {
+	return r.ok, r.err
+}
+
+// Ok converts the result into an Option, discarding the Err value if any.
+func (r Result[T, E]) Ok() optionext.Option[T] {
+	if r.isOk {
+		return optionext.Some(r.ok)
+	}
+
+	return optionext.None[T]()
+}
+
+// ErrOption converts the result into an Option of its Err value, discarding the Ok value if any.
+func (r Result[T, E]) ErrOption() optionext.Option[E] {
+	if r.isOk {
+		return optionext.None[E]()
+	}
+
+	return optionext.Some(r.err)
+}
+
+// Inspect calls the provided function with the contained Ok value, if Ok, and returns the result unchanged.
+func (r Result[T, E]) Inspect(fn func(T)) Result[T, E] {
+	if r.isOk {
+		fn(r.ok)
+	}
+
+	return r
+}
+
+// InspectErr calls the provided function with the contained Err value, if Err, and returns the result unchanged.
+func (r Result[T, E]) InspectErr(fn func(E)) Result[T, E] {
+	if !r.isOk {
+		fn(r.err)
+	}
+
+	return r
+}
@@ -0,0 +1,258 @@
+package resultext
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	// ErrInvalidResultJSON is returned when unmarshalling JSON that does not contain exactly one of `ok` or `err`.
+	ErrInvalidResultJSON = errors.New("resultext: invalid Result JSON, expected exactly one of 'ok' or 'err'")
+
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+	errorCodecsMu   sync.RWMutex
+	errorCodecs     = make(map[string]errorCodec)
+	errorCodecNames = make(map[reflect.Type]string)
+	sentinelErrors  = make(map[string]error)
+	// sentinelNames holds the names of the sentinel errors in registration order.
+	sentinelNames []string
+)
+
+type errorCodec struct {
+	encode func(err error) ([]byte, error)
+	decode func(data []byte) (error, error)
+}
+
+// encodedError is the JSON representation of an error when E is `error`.
+type encodedError struct {
+	Type    string          `json:"type"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// EncodedError is the error decoded from JSON when its type has not been registered
+// using RegisterError, RegisterErrorCodec or RegisterSentinelError.
+//
+// It preserves the original error message and the type or registered name of the original error.
+type EncodedError struct {
+	Type    string
+	Message string
+	// sentinel is the registered sentinel error the original error wrapped, if any.
+	sentinel error
+}
+
+// Error returns the original error message.
+func (e *EncodedError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the registered sentinel error the original error wrapped, if any,
+// so that `errors.Is` continues to work.
+func (e *EncodedError) Unwrap() error {
+	return e.sentinel
+}
+
+// RegisterErrorCodec registers the functions used to encode and decode the error type E
+// under the provided name when marshalling a Result with E of `error`.
+//
+// The name is stored alongside the encoded data and must be stable across versions and processes.
+func RegisterErrorCodec[E error](name string, encode func(err E) ([]byte, error), decode func(data []byte) (E, error)) {
+	errorCodecsMu.Lock()
+	defer errorCodecsMu.Unlock()
+
+	errorCodecNames[reflect.TypeOf((*E)(nil)).Elem()] = name
+	errorCodecs[name] = errorCodec{
+		encode: func(err error) ([]byte, error) {
+			return encode(err.(E))
+		},
+		decode: func(data []byte) (error, error) {
+			return decode(data)
+		},
+	}
+}
+
+// RegisterError registers the error type E under the provided name,
+// encoding and decoding it using its JSON representation.
+func RegisterError[E error](name string) {
+	RegisterErrorCodec(name,
+		func(err E) ([]byte, error) {
+			return json.Marshal(err)
+		},
+		func(data []byte) (err E, e error) {
+			if typ := reflect.TypeOf((*E)(nil)).Elem(); typ.Kind() == reflect.Pointer {
+				err = reflect.New(typ.Elem()).Interface().(E)
+				e = json.Unmarshal(data, err)
+				return
+			}
+			e = json.Unmarshal(data, &err)
+			return
+		},
+	)
+}
+
+// RegisterSentinelError registers the sentinel error, such as `io.EOF`, under the provided name,
+// decoding it back to the same error value so that `errors.Is` continues to work.
+//
+// Errors wrapping the sentinel are decoded to an *EncodedError with their original message wrapping the sentinel.
+// When an error matches multiple sentinels, the first registered is used.
+func RegisterSentinelError(name string, err error) {
+	errorCodecsMu.Lock()
+	defer errorCodecsMu.Unlock()
+
+	if _, found := sentinelErrors[name]; !found {
+		sentinelNames = append(sentinelNames, name)
+	}
+	sentinelErrors[name] = err
+}
+
+// MarshalJSON implements the `json.Marshaler` interface.
+//
+// An Ok value is encoded as `{"ok":<value>}` and an Err value as `{"err":<error>}`.
+// When E is `error` the error is encoded as `{"type":<name>,"message":<message>,"data":<data>}`,
+// see RegisterError for preserving the error type when unmarshalling.
+func (r Result[T, E]) MarshalJSON() ([]byte, error) {
+	if r.isOk {
+		return json.Marshal(struct {
+			Ok T `json:"ok"`
+		}{r.ok})
+	}
+
+	if reflect.TypeOf((*E)(nil)).Elem() != errorType {
+		return json.Marshal(struct {
+			Err E `json:"err"`
+		}{r.err})
+	}
+
+	e, err := encodeError(any(r.err).(error))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Err encodedError `json:"err"`
+	}{e})
+}
+
+// UnmarshalJSON implements the `json.Unmarshaler` interface.
+func (r *Result[T, E]) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	okData, isOk := m["ok"]
+	errData, isErr := m["err"]
+	if len(m) != 1 || isOk == isErr {
+		return ErrInvalidResultJSON
+	}
+
+	if isOk {
+		var v T
+		if err := json.Unmarshal(okData, &v); err != nil {
+			return err
+		}
+		*r = Ok[T, E](v)
+		return nil
+	}
+
+	var e E
+	if reflect.TypeOf((*E)(nil)).Elem() != errorType {
+		if err := json.Unmarshal(errData, &e); err != nil {
+			return err
+		}
+		*r = Err[T](e)
+		return nil
+	}
+
+	var encoded encodedError
+	if err := json.Unmarshal(errData, &encoded); err != nil {
+		return err
+	}
+	decoded, err := decodeError(encoded)
+	if err != nil {
+		return err
+	}
+	if decoded != nil {
+		e = decoded.(E)
+	}
+	*r = Err[T](e)
+	return nil
+}
+
+// Value implements the driver.Valuer interface storing the Result as its JSON encoding.
+func (r Result[T, E]) Value() (driver.Value, error) {
+	return r.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface reading the Result from its JSON encoding.
+func (r *Result[T, E]) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		return r.UnmarshalJSON(v)
+	case string:
+		return r.UnmarshalJSON([]byte(v))
+	default:
+		return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", value, r)
+	}
+}
+
+func encodeError(err error) (encodedError, error) {
+	if err == nil {
+		return encodedError{}, nil
+	}
+
+	errorCodecsMu.RLock()
+	defer errorCodecsMu.RUnlock()
+
+	for _, name := range sentinelNames {
+		if errors.Is(err, sentinelErrors[name]) {
+			return encodedError{Type: name, Message: err.Error()}, nil
+		}
+	}
+
+	if encoded, ok := err.(*EncodedError); ok {
+		return encodedError{Type: encoded.Type, Message: encoded.Message}, nil
+	}
+
+	name, found := errorCodecNames[reflect.TypeOf(err)]
+	if !found {
+		return encodedError{Type: fmt.Sprintf("%T", err), Message: err.Error()}, nil
+	}
+
+	data, e := errorCodecs[name].encode(err)
+	if e != nil {
+		return encodedError{}, fmt.Errorf("resultext: encoding error %s: %w", name, e)
+	}
+	return encodedError{Type: name, Message: err.Error(), Data: data}, nil
+}
+
+func decodeError(encoded encodedError) (error, error) {
+	if encoded.Type == "" && encoded.Message == "" {
+		return nil, nil
+	}
+
+	errorCodecsMu.RLock()
+	defer errorCodecsMu.RUnlock()
+
+	if sentinel, found := sentinelErrors[encoded.Type]; found {
+		if encoded.Message == sentinel.Error() {
+			return sentinel, nil
+		}
+		return &EncodedError{Type: encoded.Type, Message: encoded.Message, sentinel: sentinel}, nil
+	}
+
+	codec, found := errorCodecs[encoded.Type]
+	if !found || len(encoded.Data) == 0 {
+		return &EncodedError{Type: encoded.Type, Message: encoded.Message}, nil
+	}
+
+	err, e := codec.decode(encoded.Data)
+	if e != nil {
+		return nil, fmt.Errorf("resultext: decoding error %s: %w", encoded.Type, e)
+	}
+	return err, nil
+}
@@ -0,0 +1,137 @@
+package resultext
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+type testCodeError struct {
+	Code   int    `json:"code"`
+	Reason string `json:"reason"`
+}
+
+func (e *testCodeError) Error() string {
+	return fmt.Sprintf("code %d: %s", e.Code, e.Reason)
+}
+
+// testSliceError is a non comparable error type.
+type testSliceError []string
+
+func (e testSliceError) Error() string {
+	return strings.Join(e, ",")
+}
+
+// testEOF is a sentinel wrapping io.EOF, registered after it.
+var testEOF = fmt.Errorf("eof: %w", io.EOF)
+
+func init() {
+	RegisterError[*testCodeError]("test.code")
+	RegisterSentinelError("io.EOF", io.EOF)
+	RegisterSentinelError("test.slice", testSliceError{"sentinel"})
+	RegisterSentinelError("test.eof", testEOF)
+}
+
+func TestResultJSON(t *testing.T) {
+	type job struct {
+		Name string `json:"name"`
+	}
+
+	b, err := json.Marshal(Ok[job, error](job{Name: "a"}))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"ok":{"name":"a"}}`)
+
+	var r Result[job, error]
+	Equal(t, json.Unmarshal(b, &r), nil)
+	Equal(t, r, Ok[job, error](job{Name: "a"}))
+
+	b, err = json.Marshal(Err[job, string]("failed"))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"err":"failed"}`)
+
+	var rs Result[job, string]
+	Equal(t, json.Unmarshal(b, &rs), nil)
+	Equal(t, rs, Err[job]("failed"))
+
+	Equal(t, json.Unmarshal([]byte(`{}`), &r), ErrInvalidResultJSON)
+	Equal(t, json.Unmarshal([]byte(`{"ok":{},"err":"a"}`), &r), ErrInvalidResultJSON)
+	Equal(t, json.Unmarshal([]byte(`{"other":1}`), &r), ErrInvalidResultJSON)
+	NotEqual(t, json.Unmarshal([]byte(`[]`), &r), nil)
+}
+
+func TestResultJSONErrors(t *testing.T) {
+	// registered error type
+	b, err := json.Marshal(Err[int, error](&testCodeError{Code: 404, Reason: "not found"}))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"err":{"type":"test.code","message":"code 404: not found","data":{"code":404,"reason":"not found"}}}`)
+
+	var r Result[int, error]
+	Equal(t, json.Unmarshal(b, &r), nil)
+	var codeErr *testCodeError
+	Equal(t, errors.As(r.Err(), &codeErr), true)
+	Equal(t, codeErr.Code, 404)
+	Equal(t, codeErr.Reason, "not found")
+
+	// sentinel error
+	b, err = json.Marshal(Err[int, error](io.EOF))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"err":{"type":"io.EOF","message":"EOF"}}`)
+	Equal(t, json.Unmarshal(b, &r), nil)
+	Equal(t, errors.Is(r.Err(), io.EOF), true)
+
+	// wrapped sentinel error keeps its message and identity
+	b, err = json.Marshal(Err[int, error](fmt.Errorf("reading body: %w", io.EOF)))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"err":{"type":"io.EOF","message":"reading body: EOF"}}`)
+	Equal(t, json.Unmarshal(b, &r), nil)
+	Equal(t, errors.Is(r.Err(), io.EOF), true)
+	Equal(t, r.Err().Error(), "reading body: EOF")
+	b2, err := json.Marshal(r)
+	Equal(t, err, nil)
+	Equal(t, string(b2), string(b))
+
+	// errors matching multiple sentinels use the first registered
+	b, err = json.Marshal(Err[int, error](testEOF))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"err":{"type":"io.EOF","message":"eof: EOF"}}`)
+
+	// non comparable errors of the same type as a sentinel do not panic
+	b, err = json.Marshal(Err[int, error](testSliceError{"a"}))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"err":{"type":"resultext.testSliceError","message":"a"}}`)
+
+	// unregistered error preserves message and type
+	b, err = json.Marshal(Err[int, error](errors.New("boom")))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"err":{"type":"*errors.errorString","message":"boom"}}`)
+	Equal(t, json.Unmarshal(b, &r), nil)
+	Equal(t, r.Err().Error(), "boom")
+	var encoded *EncodedError
+	Equal(t, errors.As(r.Err(), &encoded), true)
+	Equal(t, encoded.Type, "*errors.errorString")
+
+	// re-encoding an unregistered error is stable
+	b2, err = json.Marshal(r)
+	Equal(t, err, nil)
+	Equal(t, string(b2), string(b))
+}
+
+func TestResultSQL(t *testing.T) {
+	v, err := Ok[int, error](1).Value()
+	Equal(t, err, nil)
+	Equal(t, string(v.([]byte)), `{"ok":1}`)
+
+	var r Result[int, error]
+	Equal(t, r.Scan(v), nil)
+	Equal(t, r, Ok[int, error](1))
+
+	Equal(t, r.Scan(`{"err":{"type":"io.EOF","message":"EOF"}}`), nil)
+	Equal(t, errors.Is(r.Err(), io.EOF), true)
+
+	NotEqual(t, r.Scan(1), nil)
+}
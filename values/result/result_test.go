@@ -3,8 +3,10 @@ package resultext
 import (
 	"errors"
 	"io"
+	"strconv"
 	"testing"
 
+	optionext "github.com/pchchv/extender/values/option"
 	. "github.com/pchchv/go-assert"
 )
 
@@ -53,6 +55,97 @@ func TestAndXXX(t *testing.T) {
 	Equal(t, Err[int, error](io.ErrUnexpectedEOF), ok.AndThen(func(int) Result[int, error] { return Err[int, error](io.ErrUnexpectedEOF) }))
 }
 
+func TestMapXXX(t *testing.T) {
+	ok := Ok[int, error](1)
+	err := Err[int, error](io.EOF)
+
+	Equal(t, Ok[string, error]("1"), Map(ok, strconv.Itoa))
+	Equal(t, Err[string, error](io.EOF), Map(err, strconv.Itoa))
+
+	Equal(t, Ok[int, string](1), MapErr(ok, func(e error) string { return e.Error() }))
+	Equal(t, Err[int, string]("EOF"), MapErr(err, func(e error) string { return e.Error() }))
+
+	toString := func(i int) Result[string, error] { return Ok[string, error](strconv.Itoa(i)) }
+	Equal(t, Ok[string, error]("1"), FlatMap(ok, toString))
+	Equal(t, Err[string, error](io.EOF), FlatMap(err, toString))
+	Equal(t, Err[string, error](io.ErrUnexpectedEOF), FlatMap(ok, func(int) Result[string, error] {
+		return Err[string, error](io.ErrUnexpectedEOF)
+	}))
+}
+
+func TestOrXXX(t *testing.T) {
+	ok := Ok[int, error](1)
+	err := Err[int, error](io.EOF)
+
+	Equal(t, Ok[int, string](1), Or(ok, Err[int, string]("fallback")))
+	Equal(t, Err[int, string]("fallback"), Or(err, Err[int, string]("fallback")))
+	Equal(t, Ok[int, string](2), Or(err, Ok[int, string](2)))
+
+	fallback := func(e error) Result[int, string] { return Ok[int, string](len(e.Error())) }
+	Equal(t, Ok[int, string](1), OrElse(ok, fallback))
+	Equal(t, Ok[int, string](3), OrElse(err, fallback))
+}
+
+func TestFlattenZipCollect(t *testing.T) {
+	Equal(t, Ok[int, error](1), Flatten(Ok[Result[int, error], error](Ok[int, error](1))))
+	Equal(t, Err[int, error](io.EOF), Flatten(Ok[Result[int, error], error](Err[int, error](io.EOF))))
+	Equal(t, Err[int, error](io.EOF), Flatten(Err[Result[int, error], error](io.EOF)))
+
+	add := func(a int, b string) string { return strconv.Itoa(a) + b }
+	Equal(t, Ok[string, error]("1a"), Zip(Ok[int, error](1), Ok[string, error]("a"), add))
+	Equal(t, Err[string, error](io.EOF), Zip(Err[int, error](io.EOF), Err[string, error](io.ErrUnexpectedEOF), add))
+	Equal(t, Err[string, error](io.ErrUnexpectedEOF), Zip(Ok[int, error](1), Err[string, error](io.ErrUnexpectedEOF), add))
+
+	results := []Result[int, error]{Ok[int, error](1), Err[int, error](io.EOF), Err[int, error](io.ErrUnexpectedEOF)}
+	Equal(t, Ok[[]int, error]([]int{1}), Collect(results[:1]))
+	Equal(t, Err[[]int, error](io.EOF), Collect(results))
+
+	all := CollectAll(results)
+	Equal(t, all.IsErr(), true)
+	Equal(t, errors.Is(all.Err(), io.EOF), true)
+	Equal(t, errors.Is(all.Err(), io.ErrUnexpectedEOF), true)
+	Equal(t, Ok[[]int, error]([]int{1}), CollectAll(results[:1]))
+	Equal(t, Ok[[]int, error]([]int{}), Collect[int, error](nil))
+}
+
+func TestConversions(t *testing.T) {
+	Equal(t, Ok[int, error](1), From(strconv.Atoi("1")))
+	Equal(t, From(strconv.Atoi("a")).IsErr(), true)
+
+	v, err := Ok[int, error](1).Unpack()
+	Equal(t, v, 1)
+	Equal(t, err, nil)
+	v, err = Err[int, error](io.EOF).Unpack()
+	Equal(t, v, 0)
+	Equal(t, err, io.EOF)
+
+	Equal(t, optionext.Some(1), Ok[int, error](1).Ok())
+	Equal(t, optionext.None[int](), Err[int, error](io.EOF).Ok())
+	Equal(t, optionext.None[error](), Ok[int, error](1).ErrOption())
+	Equal(t, optionext.Some(io.EOF), Err[int, error](io.EOF).ErrOption())
+
+	Equal(t, Ok[int, error](1), OkOr(optionext.Some(1), io.EOF))
+	Equal(t, Err[int, error](io.EOF), OkOr(optionext.None[int](), io.EOF))
+	Equal(t, Ok[int, error](1), OkOrElse(optionext.Some(1), func() error { return io.EOF }))
+	Equal(t, Err[int, error](io.EOF), OkOrElse(optionext.None[int](), func() error { return io.EOF }))
+}
+
+func TestInspect(t *testing.T) {
+	var inspected int
+	var inspectedErr error
+
+	ok := Ok[int, error](1)
+	Equal(t, ok, ok.Inspect(func(i int) { inspected = i }).InspectErr(func(e error) { inspectedErr = e }))
+	Equal(t, inspected, 1)
+	Equal(t, inspectedErr, nil)
+
+	inspected = 0
+	err := Err[int, error](io.EOF)
+	Equal(t, err, err.Inspect(func(i int) { inspected = i }).InspectErr(func(e error) { inspectedErr = e }))
+	Equal(t, inspected, 0)
+	Equal(t, inspectedErr, io.EOF)
+}
+
 func BenchmarkResultOk(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		if res := returnOk(); res.IsOk() {
@@ -0,0 +1,73 @@
+package resultext
+
+import (
+	"fmt"
+	"strings"
+
+	runtimeext "github.com/pchchv/extender/runtime"
+)
+
+// PanicError is the error returned by Try when the called function panics.
+type PanicError struct {
+	// Value is the value the function panicked with.
+	Value any
+
+	// Frames is the call stack at the time of the panic, starting at the frame that panicked.
+	Frames []runtimeext.Frame
+}
+
+// Error returns the panic value along with the location of the panic.
+func (e *PanicError) Error() string {
+	if len(e.Frames) == 0 {
+		return fmt.Sprintf("panic: %v", e.Value)
+	}
+
+	f := e.Frames[0]
+	return fmt.Sprintf("panic: %v [%s:%d %s]", e.Value, f.File(), f.Line(), f.Function())
+}
+
+// Unwrap returns the panic value if it is an error, allowing the use of `errors.Is` and `errors.As`.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Try calls the provided function and converts its value and error into a Result.
+//
+// A panic within the function is recovered and returned as an Err containing a *PanicError
+// with the panic value and call stack.
+func Try[T any](fn func() (T, error)) (result Result[T, error]) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = Err[T, error](&PanicError{Value: r, Frames: panicFrames()})
+		}
+	}()
+
+	return From(fn())
+}
+
+// Must returns the value if the error is nil, otherwise panics with the error.
+//
+// This is intended for use during initialization where an error is unrecoverable.
+func Must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// panicFrames returns the call stack of the recovered panic,
+// starting at the frame that panicked.
+func panicFrames() []runtimeext.Frame {
+	// skip panicFrames and the deferred function
+	frames := runtimeext.Frames(2)
+	for i, f := range frames {
+		// skip the runtime panic frames eg. runtime.gopanic, runtime.sigpanic
+		if !strings.HasPrefix(f.Frame.Function, "runtime.") {
+			return frames[i:]
+		}
+	}
+	return frames
+}
@@ -0,0 +1,50 @@
+package resultext
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	. "github.com/pchchv/go-assert"
+)
+
+func TestTry(t *testing.T) {
+	r := Try(func() (int, error) { return strconv.Atoi("1") })
+	Equal(t, r, Ok[int, error](1))
+
+	r = Try(func() (int, error) { return 0, io.EOF })
+	Equal(t, r, Err[int, error](io.EOF))
+
+	r = Try(func() (int, error) { panic("boom") })
+	Equal(t, r.IsErr(), true)
+
+	var pe *PanicError
+	Equal(t, errors.As(r.Err(), &pe), true)
+	Equal(t, pe.Value, "boom")
+	NotEqual(t, len(pe.Frames), 0)
+	Equal(t, pe.Frames[0].File(), "try_test.go")
+	Equal(t, pe.Frames[0].Function(), "func3")
+	Equal(t, errors.Unwrap(r.Err()), nil)
+
+	r = Try(func() (int, error) { panic(io.ErrUnexpectedEOF) })
+	Equal(t, errors.Is(r.Err(), io.ErrUnexpectedEOF), true)
+
+	r = Try(func() (int, error) {
+		var m map[string]int
+		m["a"] = 1
+		return 0, nil
+	})
+	Equal(t, errors.As(r.Err(), &pe), true)
+	Equal(t, pe.Frames[0].Function(), "func5")
+}
+
+func TestMust(t *testing.T) {
+	Equal(t, Must(strconv.Atoi("1")), 1)
+	PanicMatches(t, func() { Must(0, io.EOF) }, "EOF")
+}
+
+func TestExpect(t *testing.T) {
+	Equal(t, Ok[int, error](1).Expect("failed"), 1)
+	PanicMatches(t, func() { Err[int](io.EOF).Expect("reading config") }, "reading config: EOF")
+}
@@ -0,0 +1,18 @@
+package valuesext
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// scanJSON unmarshals the JSON driver value into v.
+func scanJSON(value any, v json.Unmarshaler) error {
+	switch t := value.(type) {
+	case []byte:
+		return v.UnmarshalJSON(t)
+	case string:
+		return v.UnmarshalJSON([]byte(t))
+	default:
+		return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", value, v)
+	}
+}
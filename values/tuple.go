@@ -0,0 +1,118 @@
+package valuesext
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrTupleLength is returned when unmarshalling a JSON array whose length does not match the tuple size.
+var ErrTupleLength = errors.New("valuesext: JSON array length does not match tuple size")
+
+// Pair is a tuple of two values.
+//
+// This implements the `json.Marshaler` and `json.Unmarshaler` interfaces encoded as a JSON array `[first,second]`,
+// and the sql.Scanner and driver.Valuer interfaces storing the JSON encoding.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// NewPair creates a new Pair with the given values.
+func NewPair[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
+
+// Unpack returns the Pair values.
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.First, p.Second
+}
+
+// MarshalJSON implements the `json.Marshaler` interface.
+func (p Pair[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{p.First, p.Second})
+}
+
+// UnmarshalJSON implements the `json.Unmarshaler` interface.
+func (p *Pair[A, B]) UnmarshalJSON(data []byte) error {
+	var v Pair[A, B]
+	if err := unmarshalTuple(data, &v.First, &v.Second); err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface storing the Pair as its JSON encoding.
+func (p Pair[A, B]) Value() (driver.Value, error) {
+	return p.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface reading the Pair from its JSON encoding.
+func (p *Pair[A, B]) Scan(value any) error {
+	return scanJSON(value, p)
+}
+
+// Triple is a tuple of three values.
+//
+// This implements the `json.Marshaler` and `json.Unmarshaler` interfaces encoded as a JSON array `[first,second,third]`,
+// and the sql.Scanner and driver.Valuer interfaces storing the JSON encoding.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// NewTriple creates a new Triple with the given values.
+func NewTriple[A, B, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{First: first, Second: second, Third: third}
+}
+
+// Unpack returns the Triple values.
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.First, t.Second, t.Third
+}
+
+// MarshalJSON implements the `json.Marshaler` interface.
+func (t Triple[A, B, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.First, t.Second, t.Third})
+}
+
+// UnmarshalJSON implements the `json.Unmarshaler` interface.
+func (t *Triple[A, B, C]) UnmarshalJSON(data []byte) error {
+	var v Triple[A, B, C]
+	if err := unmarshalTuple(data, &v.First, &v.Second, &v.Third); err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface storing the Triple as its JSON encoding.
+func (t Triple[A, B, C]) Value() (driver.Value, error) {
+	return t.MarshalJSON()
+}
+
+// Scan implements the sql.Scanner interface reading the Triple from its JSON encoding.
+func (t *Triple[A, B, C]) Scan(value any) error {
+	return scanJSON(value, t)
+}
+
+// unmarshalTuple unmarshals the JSON array into the provided pointers, which must match the array length.
+func unmarshalTuple(data []byte, ptrs ...any) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return err
+	}
+	if len(elems) != len(ptrs) {
+		return fmt.Errorf("%w: got %d, want %d", ErrTupleLength, len(elems), len(ptrs))
+	}
+
+	for i, elem := range elems {
+		if err := json.Unmarshal(elem, ptrs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
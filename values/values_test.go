@@ -0,0 +1,183 @@
+package valuesext
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+
+	optionext "github.com/pchchv/extender/values/option"
+	resultext "github.com/pchchv/extender/values/result"
+	. "github.com/pchchv/go-assert"
+)
+
+func TestEither(t *testing.T) {
+	l := Left[int, string](1)
+	Equal(t, l.IsLeft(), true)
+	Equal(t, l.IsRight(), false)
+	Equal(t, l.Left(), optionext.Some(1))
+	Equal(t, l.Right(), optionext.None[string]())
+	Equal(t, l.UnwrapLeft(), 1)
+	PanicMatches(t, func() { l.UnwrapRight() }, "Either.UnwrapRight: either is Left")
+
+	r := Right[int]("a")
+	Equal(t, r.IsRight(), true)
+	Equal(t, r.UnwrapRight(), "a")
+	PanicMatches(t, func() { r.UnwrapLeft() }, "Either.UnwrapLeft: either is Right")
+	Equal(t, r.Swap(), Left[string, int]("a"))
+
+	Equal(t, MapLeft(l, strconv.Itoa), Left[string, string]("1"))
+	Equal(t, MapRight(l, func(s string) int { return len(s) }), Left[int, int](1))
+	Equal(t, MapRight(r, func(s string) int { return len(s) }), Right[int](1))
+	Equal(t, Fold(r, strconv.Itoa, func(s string) string { return s + "!" }), "a!")
+}
+
+func TestEitherJSON(t *testing.T) {
+	b, err := json.Marshal(Left[int, string](1))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"left":1}`)
+
+	var e Either[int, string]
+	Equal(t, json.Unmarshal(b, &e), nil)
+	Equal(t, e, Left[int, string](1))
+
+	b, err = json.Marshal(Right[int]("a"))
+	Equal(t, err, nil)
+	Equal(t, string(b), `{"right":"a"}`)
+	Equal(t, json.Unmarshal(b, &e), nil)
+	Equal(t, e, Right[int]("a"))
+
+	Equal(t, json.Unmarshal([]byte(`{}`), &e), ErrInvalidEitherJSON)
+	Equal(t, json.Unmarshal([]byte(`{"left":1,"right":"a"}`), &e), ErrInvalidEitherJSON)
+	NotEqual(t, json.Unmarshal([]byte(`{"left":"a"}`), &e), nil)
+
+	v, err := Right[int]("a").Value()
+	Equal(t, err, nil)
+	Equal(t, e.Scan(v), nil)
+	Equal(t, e, Right[int]("a"))
+	Equal(t, e.Scan(`{"left":2}`), nil)
+	Equal(t, e, Left[int, string](2))
+	NotEqual(t, e.Scan(1), nil)
+}
+
+func TestTuples(t *testing.T) {
+	p := NewPair(1, "a")
+	a, b := p.Unpack()
+	Equal(t, a, 1)
+	Equal(t, b, "a")
+
+	data, err := json.Marshal(p)
+	Equal(t, err, nil)
+	Equal(t, string(data), `[1,"a"]`)
+
+	var p2 Pair[int, string]
+	Equal(t, json.Unmarshal(data, &p2), nil)
+	Equal(t, p2, p)
+
+	err = json.Unmarshal([]byte(`[1]`), &p2)
+	Equal(t, errors.Is(err, ErrTupleLength), true)
+	Equal(t, err.Error(), "valuesext: JSON array length does not match tuple size: got 1, want 2")
+	NotEqual(t, json.Unmarshal([]byte(`["a","a"]`), &p2), nil)
+	Equal(t, p2, p)
+
+	tr := NewTriple(1, "a", true)
+	data, err = json.Marshal(tr)
+	Equal(t, err, nil)
+	Equal(t, string(data), `[1,"a",true]`)
+
+	var tr2 Triple[int, string, bool]
+	Equal(t, json.Unmarshal(data, &tr2), nil)
+	Equal(t, tr2, tr)
+	x, y, z := tr2.Unpack()
+	Equal(t, x, 1)
+	Equal(t, y, "a")
+	Equal(t, z, true)
+	Equal(t, errors.Is(json.Unmarshal([]byte(`[1,"a"]`), &tr2), ErrTupleLength), true)
+
+	v, err := p.Value()
+	Equal(t, err, nil)
+	p2 = Pair[int, string]{}
+	Equal(t, p2.Scan(v), nil)
+	Equal(t, p2, p)
+
+	v, err = tr.Value()
+	Equal(t, err, nil)
+	tr2 = Triple[int, string, bool]{}
+	Equal(t, tr2.Scan(v), nil)
+	Equal(t, tr2, tr)
+}
+
+func TestNonEmptySlice(t *testing.T) {
+	n := NewNonEmptySlice(1, 2, 3)
+	Equal(t, n.First(), 1)
+	Equal(t, n.Last(), 3)
+	Equal(t, n.Len(), 3)
+	Equal(t, n.Slice(), []int{1, 2, 3})
+	Equal(t, n.Append(4).Slice(), []int{1, 2, 3, 4})
+	Equal(t, n.Len(), 3)
+
+	var values []int
+	for v := range n.All() {
+		values = append(values, v)
+	}
+	Equal(t, values, []int{1, 2, 3})
+
+	Equal(t, NonEmptySliceFrom([]int{}), optionext.None[NonEmptySlice[int]]())
+	Equal(t, NonEmptySliceFrom([]int{1}).Unwrap().Slice(), []int{1})
+
+	data, err := json.Marshal(n)
+	Equal(t, err, nil)
+	Equal(t, string(data), `[1,2,3]`)
+
+	var n2 NonEmptySlice[int]
+	Equal(t, json.Unmarshal(data, &n2), nil)
+	Equal(t, n2.Slice(), []int{1, 2, 3})
+	Equal(t, json.Unmarshal([]byte(`[]`), &n2), ErrEmptySlice)
+	Equal(t, json.Unmarshal([]byte(`null`), &n2), ErrEmptySlice)
+	Equal(t, n2.Slice(), []int{1, 2, 3})
+
+	type request struct {
+		IDs NonEmptySlice[int] `json:"ids"`
+	}
+	var req request
+	Equal(t, json.Unmarshal([]byte(`{"ids":[]}`), &req), ErrEmptySlice)
+
+	_, err = json.Marshal(NonEmptySlice[int]{})
+	NotEqual(t, err, nil)
+
+	v, err := n.Value()
+	Equal(t, err, nil)
+	n2 = NonEmptySlice[int]{}
+	Equal(t, n2.Scan(v), nil)
+	Equal(t, n2.Slice(), []int{1, 2, 3})
+	Equal(t, n2.Scan("[]"), ErrEmptySlice)
+}
+
+func TestLazy(t *testing.T) {
+	var calls int
+	l := NewLazy(func() (int, error) {
+		calls++
+		return 1, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Equal(t, l.Get(), resultext.Ok[int, error](1))
+		}()
+	}
+	wg.Wait()
+	Equal(t, calls, 1)
+
+	le := NewLazy(func() (int, error) { return 0, io.EOF })
+	Equal(t, le.Get(), resultext.Err[int, error](io.EOF))
+
+	lp := NewLazy(func() (int, error) { panic("boom") })
+	Equal(t, lp.Get().IsErr(), true)
+	var pe *resultext.PanicError
+	Equal(t, errors.As(lp.Get().Err(), &pe), true)
+}